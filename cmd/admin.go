@@ -0,0 +1,25 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"code.gitea.io/tea/cmd/admin"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdAdmin represents the site administration commands
+var CmdAdmin = cli.Command{
+	Name:        "admin",
+	Category:    catAdmin,
+	Usage:       "Administrate the Gitea instance",
+	Description: "Commands for site administrators, to manage users, organizations, repos and cron tasks",
+	Subcommands: []*cli.Command{
+		&admin.CmdAdminUsers,
+		&admin.CmdAdminOrgs,
+		&admin.CmdAdminRepos,
+		&admin.CmdAdminCron,
+	},
+}
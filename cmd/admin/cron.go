@@ -0,0 +1,85 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdAdminCron represents a sub command of admin to manage cron tasks
+var CmdAdminCron = cli.Command{
+	Name:        "cron",
+	Usage:       "List and run cron tasks",
+	Description: "Lists all cron tasks when called without sub command",
+	Action:      runAdminCronList,
+	Subcommands: []*cli.Command{
+		&CmdAdminCronList,
+		&CmdAdminCronRun,
+	},
+	Flags: adminCronListFlags,
+}
+
+var adminCronListFlags = append([]cli.Flag{
+	&flags.PaginationPageFlag,
+	&flags.PaginationLimitFlag,
+}, flags.LoginOutputFlags...)
+
+// CmdAdminCronList represents a sub command of cron to list the tasks
+var CmdAdminCronList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List cron tasks",
+	Description: "List cron tasks with their schedule and last execution",
+	Action:      runAdminCronList,
+	Flags:       adminCronListFlags,
+}
+
+// CmdAdminCronRun represents a sub command of cron to trigger tasks
+var CmdAdminCronRun = cli.Command{
+	Name:        "run",
+	Usage:       "Run cron tasks now",
+	Description: "Trigger cron tasks, which are then executed in the background by the server",
+	ArgsUsage:   "<task> [<task>...]",
+	Action:      runAdminCronRun,
+	Flags:       []cli.Flag{&flags.LoginFlag},
+}
+
+func runAdminCronList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	tasks, _, err := ctx.Login.Client().ListCronTasks(gitea.ListCronTaskOptions{
+		ListOptions: ctx.GetListOptions(),
+	})
+	if err != nil {
+		return err
+	}
+
+	print.CronTasksList(tasks, ctx.Output)
+	return nil
+}
+
+func runAdminCronRun(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if ctx.Args().Len() == 0 {
+		return fmt.Errorf("You have to specify at least one task, see 'tea admin cron list'")
+	}
+
+	for _, task := range ctx.Args().Slice() {
+		if _, err := client.RunCronTasks(task); err != nil {
+			return fmt.Errorf("could not run %s: %s", task, err)
+		}
+		fmt.Printf("Triggered %s\n", task)
+	}
+	return nil
+}
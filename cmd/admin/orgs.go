@@ -0,0 +1,99 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/cmd/organizations"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdAdminOrgs represents a sub command of admin to manage organizations
+var CmdAdminOrgs = cli.Command{
+	Name:        "orgs",
+	Aliases:     []string{"org", "o"},
+	Usage:       "Manage all organizations",
+	Description: "Lists all organizations when called without sub command",
+	Action:      runAdminOrgsList,
+	Subcommands: []*cli.Command{
+		&CmdAdminOrgsList,
+		&CmdAdminOrgsCreate,
+	},
+	Flags: adminOrgsListFlags,
+}
+
+var adminOrgsListFlags = append([]cli.Flag{
+	&flags.PaginationPageFlag,
+	&flags.PaginationLimitFlag,
+}, flags.LoginOutputFlags...)
+
+// CmdAdminOrgsList represents a sub command of orgs to list all organizations
+var CmdAdminOrgsList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List all organizations",
+	Description: "List all organizations of the instance",
+	Action:      runAdminOrgsList,
+	Flags:       adminOrgsListFlags,
+}
+
+// CmdAdminOrgsCreate represents a sub command of orgs to create one for any user
+var CmdAdminOrgsCreate = cli.Command{
+	Name:        "create",
+	Aliases:     []string{"c"},
+	Usage:       "Create an organization owned by any user",
+	Description: "Create an organization, with the given user as member of its owners team",
+	ArgsUsage:   "<organization name>",
+	Action:      runAdminOrgsCreate,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:     "owner",
+			Aliases:  []string{"O"},
+			Usage:    "user owning the organization",
+			Required: true,
+		},
+	}, organizations.CmdOrganizationCreate.Flags...),
+}
+
+func runAdminOrgsList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	orgs, _, err := ctx.Login.Client().AdminListOrgs(gitea.AdminListOrgsOptions{
+		ListOptions: ctx.GetListOptions(),
+	})
+	if err != nil {
+		return err
+	}
+
+	print.OrganizationsList(orgs, ctx.Output)
+	return nil
+}
+
+func runAdminOrgsCreate(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("You have to specify the organization name you want to create")
+	}
+
+	opts, err := organizations.GetCreateOrgOption(ctx)
+	if err != nil {
+		return err
+	}
+
+	org, _, err := ctx.Login.Client().AdminCreateOrg(ctx.String("owner"), *opts)
+	if err != nil {
+		return err
+	}
+
+	print.OrganizationDetails(org, nil, nil)
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/repos"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdAdminRepos represents a sub command of admin to manage repos
+var CmdAdminRepos = cli.Command{
+	Name:        "repos",
+	Aliases:     []string{"repo", "r"},
+	Usage:       "Manage repos of any owner",
+	Description: "Manage repos of any owner",
+	Subcommands: []*cli.Command{
+		&CmdAdminReposCreate,
+	},
+}
+
+// CmdAdminReposCreate represents a sub command of repos to create one for any owner
+var CmdAdminReposCreate = cli.Command{
+	Name:        "create",
+	Aliases:     []string{"c"},
+	Usage:       "Create a repo for any user or organization",
+	Description: "Create a repo for any user or organization, which has to be given with --owner",
+	Action:      runAdminReposCreate,
+	Flags:       repos.CmdRepoCreate.Flags,
+}
+
+func runAdminReposCreate(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	owner := ctx.String("owner")
+	if len(owner) == 0 {
+		return fmt.Errorf("You have to specify the owner of the repo with --owner")
+	}
+
+	opts, err := repos.GetCreateRepoOption(ctx)
+	if err != nil {
+		return err
+	}
+
+	repo, _, err := client.AdminCreateRepo(owner, *opts)
+	if err != nil {
+		return err
+	}
+
+	topics, _, err := client.ListRepoTopics(repo.Owner.UserName, repo.Name, gitea.ListRepoTopicsOptions{})
+	if err != nil {
+		return err
+	}
+	print.RepoDetails(repo, topics)
+
+	fmt.Printf("%s\n", repo.HTMLURL)
+	return nil
+}
@@ -0,0 +1,246 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/cmd/organizations"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdAdminUsers represents a sub command of admin to manage users
+var CmdAdminUsers = cli.Command{
+	Name:        "users",
+	Aliases:     []string{"user", "u"},
+	Usage:       "Manage user accounts",
+	Description: "Lists all users when called without sub command",
+	Action:      runAdminUsersList,
+	Subcommands: []*cli.Command{
+		&CmdAdminUsersList,
+		&CmdAdminUsersCreate,
+		&CmdAdminUsersDelete,
+		&CmdAdminUsersImport,
+		editUsersCommand("suspend", "Prohibit users from logging in", "Suspended", func(opts *gitea.EditUserOption) {
+			opts.ProhibitLogin = gitea.OptionalBool(true)
+		}),
+		editUsersCommand("unsuspend", "Allow suspended users to log in again", "Unsuspended", func(opts *gitea.EditUserOption) {
+			opts.ProhibitLogin = gitea.OptionalBool(false)
+		}),
+		editUsersCommand("restrict", "Restrict users to repos and orgs they are explicitly added to", "Restricted", func(opts *gitea.EditUserOption) {
+			opts.Restricted = gitea.OptionalBool(true)
+		}),
+		editUsersCommand("unrestrict", "Lift the restriction of users", "Unrestricted", func(opts *gitea.EditUserOption) {
+			opts.Restricted = gitea.OptionalBool(false)
+		}),
+		editUsersCommand("promote", "Make users site administrators", "Promoted", func(opts *gitea.EditUserOption) {
+			opts.Admin = gitea.OptionalBool(true)
+		}),
+		editUsersCommand("demote", "Revoke site administrator rights of users", "Demoted", func(opts *gitea.EditUserOption) {
+			opts.Admin = gitea.OptionalBool(false)
+		}),
+	},
+	Flags: adminUsersListFlags,
+}
+
+var adminUserFieldsFlag = flags.FieldsFlag(print.UserFields, []string{
+	"id", "login", "full_name", "email", "active", "last_login",
+})
+
+var adminUsersListFlags = append([]cli.Flag{
+	adminUserFieldsFlag,
+	&flags.PaginationPageFlag,
+	&flags.PaginationLimitFlag,
+}, flags.LoginOutputFlags...)
+
+// CmdAdminUsersList represents a sub command of users to list all users
+var CmdAdminUsersList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List all users",
+	Description: "List all users of the instance",
+	Action:      runAdminUsersList,
+	Flags:       adminUsersListFlags,
+}
+
+// CmdAdminUsersCreate represents a sub command of users to create one
+var CmdAdminUsersCreate = cli.Command{
+	Name:        "create",
+	Aliases:     []string{"c"},
+	Usage:       "Create a user",
+	Description: "Create a user. If no password is given, a random one is generated and printed.",
+	ArgsUsage:   "<username>",
+	Action:      runAdminUsersCreate,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "email",
+			Aliases:  []string{"e"},
+			Usage:    "email address of the user",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "full-name",
+			Usage: "full name of the user",
+		},
+		&cli.StringFlag{
+			Name:    "password",
+			Aliases: []string{"p"},
+			Usage:   "initial password, generated if not given",
+		},
+		&cli.BoolFlag{
+			Name:  "must-change-password",
+			Usage: "require the user to change the password on first login",
+			Value: true,
+		},
+		&cli.BoolFlag{
+			Name:  "send-notify",
+			Usage: "send a notification email to the user",
+		},
+		&cli.StringFlag{
+			Name:    "visibility",
+			Aliases: []string{"v"},
+			Usage:   "public, limited or private",
+		},
+		&flags.LoginFlag,
+	},
+}
+
+// CmdAdminUsersDelete represents a sub command of users to delete them
+var CmdAdminUsersDelete = cli.Command{
+	Name:        "delete",
+	Aliases:     []string{"rm"},
+	Usage:       "Delete users",
+	Description: "Delete users. Users still owning repos or organizations can't be deleted.",
+	ArgsUsage:   "<username> [<username>...]",
+	Action:      runAdminUsersDelete,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "confirm",
+			Aliases: []string{"y"},
+			Usage:   "Confirm deletion (required)",
+		},
+		&flags.LoginFlag,
+	},
+}
+
+func runAdminUsersList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	users, _, err := ctx.Login.Client().AdminListUsers(gitea.AdminListUsersOptions{
+		ListOptions: ctx.GetListOptions(),
+	})
+	if err != nil {
+		return err
+	}
+
+	fields, err := adminUserFieldsFlag.GetValues(cmd)
+	if err != nil {
+		return err
+	}
+	print.UserList(users, ctx.Output, fields)
+	return nil
+}
+
+func runAdminUsersCreate(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("You have to specify the username")
+	}
+
+	password := ctx.String("password")
+	generated := len(password) == 0
+	if generated {
+		var err error
+		if password, err = task.GeneratePassword(16); err != nil {
+			return err
+		}
+	}
+
+	opts := gitea.CreateUserOption{
+		Username:           ctx.Args().First(),
+		Email:              ctx.String("email"),
+		FullName:           ctx.String("full-name"),
+		Password:           password,
+		MustChangePassword: gitea.OptionalBool(ctx.Bool("must-change-password")),
+		SendNotify:         ctx.Bool("send-notify"),
+	}
+	if ctx.IsSet("visibility") {
+		visibility, err := organizations.GetVisibility(ctx.String("visibility"))
+		if err != nil {
+			return err
+		}
+		opts.Visibility = &visibility
+	}
+
+	user, _, err := ctx.Login.Client().AdminCreateUser(opts)
+	if err != nil {
+		return err
+	}
+
+	print.UserDetails(user)
+	if generated {
+		fmt.Printf("Password: %s\n", password)
+	}
+	return nil
+}
+
+func runAdminUsersDelete(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if ctx.Args().Len() == 0 {
+		return fmt.Errorf("You have to specify at least one username")
+	}
+
+	if !ctx.Bool("confirm") {
+		fmt.Println("Are you sure? Please confirm with -y or --confirm.")
+		return nil
+	}
+
+	for _, user := range ctx.Args().Slice() {
+		if _, err := client.AdminDeleteUser(user); err != nil {
+			return fmt.Errorf("could not delete %s: %s", user, err)
+		}
+		fmt.Printf("Deleted %s\n", user)
+	}
+	return nil
+}
+
+// editUsersCommand creates a command which applies the same change to the given users
+func editUsersCommand(name, usage, message string, edit func(opts *gitea.EditUserOption)) *cli.Command {
+	return &cli.Command{
+		Name:        name,
+		Usage:       usage,
+		Description: usage,
+		ArgsUsage:   "<username> [<username>...]",
+		Action: func(cmd *cli.Context) error {
+			ctx := context.InitCommand(cmd)
+			client := ctx.Login.Client()
+
+			if ctx.Args().Len() == 0 {
+				return fmt.Errorf("You have to specify at least one username")
+			}
+
+			for _, user := range ctx.Args().Slice() {
+				// login name is required by the API, source 0 leaves the auth source unchanged
+				opts := gitea.EditUserOption{LoginName: user}
+				edit(&opts)
+				if _, err := client.AdminEditUser(user, opts); err != nil {
+					return fmt.Errorf("could not edit %s: %s", user, err)
+				}
+				fmt.Printf("%s %s\n", message, user)
+			}
+			return nil
+		},
+		Flags: []cli.Flag{&flags.LoginFlag},
+	}
+}
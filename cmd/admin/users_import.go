@@ -0,0 +1,93 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/task"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdAdminUsersImport represents a sub command of users to create and update users from a CSV or LDIF file
+var CmdAdminUsersImport = cli.Command{
+	Name:  "import",
+	Usage: "Create and update users from a CSV or LDIF file",
+	Description: `Creates missing users, updates email and full name of existing users
+(an empty full name is left unchanged) and adds them to teams, as described in a CSV or LDIF file. Running an import again
+only applies what changed since. Users are never deleted or removed from teams.
+
+The first line names the columns. username and email are required, optional
+are full_name, password, must_change_password (default true, only applied to
+new users) and teams, a list of <org>/<team> separated by ';'.
+Lines starting with # are ignored:
+
+	username,email,full_name,teams
+	alice,alice@example.com,Alice Liddell,cs/students;cs/tutors
+
+Files ending with .ldif are read as LDIF, e.g. as exported from an LDAP
+directory. uid is used as username, mail as email and displayName or cn as
+full name. Entries without uid are skipped. Passwords and teams are not read
+from LDIF, and all new users have to change their password.
+
+Users without a password get a random one, which is printed, or written to
+the file given by --passwords.`,
+	ArgsUsage: "<users.csv|users.ldif>",
+	Action:    runAdminUsersImport,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Only show the changes, don't apply them",
+		},
+		&cli.StringFlag{
+			Name:  "passwords",
+			Usage: "CSV file to write the generated passwords to",
+		},
+		&flags.LoginFlag,
+	},
+}
+
+func runAdminUsersImport(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("You have to specify the CSV or LDIF file to import")
+	}
+
+	users, err := task.ReadUserImports(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	var passwords io.Writer
+	if path := ctx.String("passwords"); len(path) != 0 && !ctx.Bool("dry-run") {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		passwords = f
+	}
+
+	summary, err := task.ImportUsers(ctx.Login.Client(), users, ctx.Bool("dry-run"), passwords)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d created, %d updated, %d unchanged, %d added to teams, %d failed\n",
+		summary.Created, summary.Updated, summary.Unchanged, summary.Memberships, summary.Failed)
+	if ctx.Bool("dry-run") {
+		fmt.Println("This was a dry run, nothing was changed.")
+	}
+	if summary.Failed != 0 {
+		return fmt.Errorf("import finished with %d failures", summary.Failed)
+	}
+	return nil
+}
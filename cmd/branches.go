@@ -0,0 +1,51 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"code.gitea.io/tea/cmd/branches"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdBranches represents to operate repositories' branches.
+var CmdBranches = cli.Command{
+	Name:        "branches",
+	Aliases:     []string{"branch", "b"},
+	Category:    catEntities,
+	Usage:       "Manage branches and their protection rules",
+	Description: `Lists branches when called without argument. If a branch name is provided, will show it in detail.`,
+	ArgsUsage:   "[<branch name>]",
+	Action:      runBranches,
+	Subcommands: []*cli.Command{
+		&branches.CmdBranchesList,
+		&branches.CmdBranchesCreate,
+		&branches.CmdBranchesDelete,
+		&branches.CmdBranchesProtect,
+	},
+	Flags: branches.CmdBranchesList.Flags,
+}
+
+func runBranches(ctx *cli.Context) error {
+	if ctx.Args().Len() == 1 {
+		return runBranchDetail(ctx, ctx.Args().First())
+	}
+	return branches.RunBranchesList(ctx)
+}
+
+func runBranchDetail(cmd *cli.Context, name string) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+
+	branch, _, err := ctx.Login.Client().GetRepoBranch(ctx.Owner, ctx.Repo, name)
+	if err != nil {
+		return err
+	}
+
+	print.BranchDetails(branch)
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package branches
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdBranchesCreate represents a sub command of branches to create a branch
+var CmdBranchesCreate = cli.Command{
+	Name:        "create",
+	Aliases:     []string{"c"},
+	Usage:       "Create a branch",
+	Description: "Create a branch on the server, based on an existing branch",
+	ArgsUsage:   "<branch name>",
+	Action:      runBranchesCreate,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:    "from",
+			Aliases: []string{"f"},
+			Usage:   "Branch to base the new branch on, defaults to the default branch",
+		},
+	}, flags.AllDefaultFlags...),
+}
+
+func runBranchesCreate(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a branch name")
+	}
+
+	branch, _, err := ctx.Login.Client().CreateBranch(ctx.Owner, ctx.Repo, gitea.CreateBranchOption{
+		BranchName:    ctx.Args().First(),
+		OldBranchName: ctx.String("from"),
+	})
+	if err != nil {
+		return err
+	}
+
+	print.BranchDetails(branch)
+	return nil
+}
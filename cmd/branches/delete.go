@@ -0,0 +1,58 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package branches
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdBranchesDelete represents a sub command of branches to delete branches
+var CmdBranchesDelete = cli.Command{
+	Name:        "delete",
+	Aliases:     []string{"rm"},
+	Usage:       "Delete one or more branches",
+	Description: "Delete one or more branches on the server",
+	ArgsUsage:   "<branch name> [<branch name>...]",
+	Action:      runBranchesDelete,
+	Flags: append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:    "confirm",
+			Aliases: []string{"y"},
+			Usage:   "Confirm deletion (required)",
+		},
+	}, flags.AllDefaultFlags...),
+}
+
+func runBranchesDelete(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+	client := ctx.Login.Client()
+
+	if !ctx.Args().Present() {
+		return fmt.Errorf("Must specify a branch name")
+	}
+
+	if !ctx.Bool("confirm") {
+		fmt.Println("Are you sure? Please confirm with -y or --confirm.")
+		return nil
+	}
+
+	for _, name := range ctx.Args().Slice() {
+		deleted, _, err := client.DeleteRepoBranch(ctx.Owner, ctx.Repo, name)
+		if err != nil {
+			return fmt.Errorf("could not delete branch '%s': %s", name, err)
+		}
+		if !deleted {
+			return fmt.Errorf("could not delete branch '%s'", name)
+		}
+		fmt.Printf("Deleted branch '%s'\n", name)
+	}
+	return nil
+}
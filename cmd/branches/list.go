@@ -0,0 +1,123 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package branches
+
+import (
+	"fmt"
+	"os"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+var branchFieldsFlag = flags.FieldsFlag(print.BranchFields, []string{
+	"name", "protected", "sha", "message", "updated",
+})
+
+// CmdBranchesList represents a sub command of branches to list branches
+var CmdBranchesList = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Usage:   "List branches of the repository",
+	Description: `List branches of the repository.
+The --merged filter searches the last --merged-depth commits of the default
+branch, so branches merged before are not listed.
+The ahead & behind fields count commits relative to the default branch, and
+are only available when run within an up to date clone of the repository.`,
+	Action: RunBranchesList,
+	Flags: append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:  "merged",
+			Usage: "Only list branches that are fully merged into the default branch",
+		},
+		&cli.IntFlag{
+			Name:  "merged-depth",
+			Usage: "Number of commits of the default branch to search with --merged",
+			Value: 500,
+		},
+		branchFieldsFlag,
+		&flags.PaginationPageFlag,
+		&flags.PaginationLimitFlag,
+	}, flags.AllDefaultFlags...),
+}
+
+// RunBranchesList list branches
+func RunBranchesList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+	client := ctx.Login.Client()
+
+	branches, _, err := client.ListRepoBranches(ctx.Owner, ctx.Repo, gitea.ListRepoBranchesOptions{
+		ListOptions: ctx.GetListOptions(),
+	})
+	if err != nil {
+		return err
+	}
+
+	fields, err := branchFieldsFlag.GetValues(cmd)
+	if err != nil {
+		return err
+	}
+
+	var defaultBranch string
+	if ctx.Bool("merged") || hasField(fields, "ahead", "behind") {
+		repo, _, err := client.GetRepo(ctx.Owner, ctx.Repo)
+		if err != nil {
+			return err
+		}
+		defaultBranch = repo.DefaultBranch
+	}
+
+	if ctx.Bool("merged") {
+		var complete bool
+		depth := ctx.Int("merged-depth")
+		branches, complete, err = task.MergedBranches(client, ctx.Owner, ctx.Repo, defaultBranch, branches, depth)
+		if err != nil {
+			return err
+		}
+		if !complete {
+			fmt.Fprintf(os.Stderr, "Note: only the last %d commits of %s were searched, branches merged before are not listed. Raise --merged-depth to search further.\n", depth, defaultBranch)
+		}
+	}
+
+	var ahead, behind map[string]int
+	if hasField(fields, "ahead", "behind") {
+		if ctx.LocalRepo == nil {
+			fmt.Fprintln(os.Stderr, "Note: ahead & behind are only available within a local clone of the repository")
+		} else {
+			base, _, err := client.GetRepoBranch(ctx.Owner, ctx.Repo, defaultBranch)
+			if err != nil {
+				return err
+			}
+			if base.Commit == nil {
+				return fmt.Errorf("default branch %s has no commit", defaultBranch)
+			}
+			var missing int
+			ahead, behind, missing = task.BranchDivergence(ctx.LocalRepo, base.Commit.ID, branches)
+			if missing != 0 {
+				fmt.Fprintf(os.Stderr, "Note: %d branches are not available locally, run 'git fetch' to count their commits ahead & behind\n", missing)
+			}
+		}
+	}
+
+	print.BranchesList(branches, ahead, behind, ctx.Output, fields)
+	return nil
+}
+
+func hasField(fields []string, names ...string) bool {
+	for _, f := range fields {
+		for _, n := range names {
+			if f == n {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package branches
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdBranchesProtect represents a sub command of branches to manage branch protections
+var CmdBranchesProtect = cli.Command{
+	Name:        "protect",
+	Aliases:     []string{"p"},
+	Usage:       "Manage branch protection rules",
+	Description: "Lists branch protection rules when called without argument. If a branch name is provided, will show its rule in detail.",
+	ArgsUsage:   "[<branch name>]",
+	Action:      runBranchProtections,
+	Subcommands: []*cli.Command{
+		&CmdBranchProtectionsList,
+		&CmdBranchProtectionsApply,
+		&CmdBranchProtectionsExport,
+	},
+	Flags: flags.AllDefaultFlags,
+}
+
+// CmdBranchProtectionsList represents a sub command of branch protections to list them
+var CmdBranchProtectionsList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List branch protection rules",
+	Description: "List branch protection rules",
+	Action:      runBranchProtectionsList,
+	Flags:       flags.AllDefaultFlags,
+}
+
+// CmdBranchProtectionsApply represents a sub command of branch protections to apply rules from a file
+var CmdBranchProtectionsApply = cli.Command{
+	Name:    "apply",
+	Usage:   "Create or update branch protection rules from a YAML file",
+	Aliases: []string{"a"},
+	Description: `Create or update branch protection rules from a YAML file, containing a list of rules:
+
+	- branch: main
+	  enable_push: true
+	  enable_push_whitelist: true
+	  push_whitelist_teams: [Owners]
+	  required_approvals: 2
+	  dismiss_stale_approvals: true
+	  enable_status_check: true
+	  status_check_contexts: [ci/drone/pr]
+
+The rules are applied to the current repo, or to all repos given as arguments.
+Use 'tea branches protect export' to get a file from existing rules.`,
+	ArgsUsage: "[<owner>/<repo>...]",
+	Action:    runBranchProtectionsApply,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:     "file",
+			Aliases:  []string{"f"},
+			Usage:    "YAML file containing the rules",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:  "diff",
+			Usage: "Only show the changes compared to the rules on the server, don't apply them",
+		},
+		&cli.BoolFlag{
+			Name:  "prune",
+			Usage: "Delete rules on the server which are not contained in the file",
+		},
+	}, flags.LoginRepoFlags...),
+}
+
+// CmdBranchProtectionsExport represents a sub command of branch protections to save rules to a file
+var CmdBranchProtectionsExport = cli.Command{
+	Name:        "export",
+	Usage:       "Save the branch protection rules of the repo as a YAML file",
+	Description: "Save the branch protection rules of the repo as a YAML file, to be used with 'tea branches protect apply'",
+	ArgsUsage:   "<file>",
+	Action:      runBranchProtectionsExport,
+	Flags:       flags.LoginRepoFlags,
+}
+
+func runBranchProtections(cmd *cli.Context) error {
+	if cmd.Args().Len() == 1 {
+		return runBranchProtectionDetail(cmd, cmd.Args().First())
+	}
+	return runBranchProtectionsList(cmd)
+}
+
+func runBranchProtectionDetail(cmd *cli.Context, branch string) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+
+	bp, _, err := ctx.Login.Client().GetBranchProtection(ctx.Owner, ctx.Repo, branch)
+	if err != nil {
+		return err
+	}
+
+	print.BranchProtectionDetails(bp)
+	return nil
+}
+
+func runBranchProtectionsList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+
+	protections, _, err := ctx.Login.Client().ListBranchProtections(ctx.Owner, ctx.Repo, gitea.ListBranchProtectionsOptions{})
+	if err != nil {
+		return err
+	}
+
+	print.BranchProtectionsList(protections, ctx.Output)
+	return nil
+}
+
+func runBranchProtectionsApply(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	rules, err := task.ReadBranchProtectionRules(ctx.String("file"))
+	if err != nil {
+		return err
+	}
+
+	repos := ctx.Args().Slice()
+	if len(repos) == 0 {
+		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+		repos = []string{ctx.RepoSlug}
+	}
+
+	for _, slug := range repos {
+		owner, repo := utils.GetOwnerAndRepo(slug, ctx.Login.User)
+		if err := task.ApplyBranchProtections(client, owner, repo, rules, ctx.Bool("diff"), ctx.Bool("prune")); err != nil {
+			return fmt.Errorf("could not apply rules to %s/%s: %s", owner, repo, err)
+		}
+	}
+	return nil
+}
+
+func runBranchProtectionsExport(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a file name")
+	}
+
+	protections, _, err := ctx.Login.Client().ListBranchProtections(ctx.Owner, ctx.Repo, gitea.ListBranchProtectionsOptions{})
+	if err != nil {
+		return err
+	}
+
+	rules := make([]task.BranchProtectionRule, len(protections))
+	for i, bp := range protections {
+		rules[i] = task.BranchProtectionToRule(bp)
+	}
+	return task.WriteBranchProtectionRules(rules, ctx.Args().First())
+}
@@ -23,6 +23,36 @@ var LoginFlag = cli.StringFlag{
 	Usage:   "Use a different Gitea Login. Optional",
 }
 
+// SudoFlag provides a global flag to run commands as another user
+var SudoFlag = cli.StringFlag{
+	Name:    "sudo",
+	EnvVars: []string{"TEA_SUDO"},
+	Usage:   "Run the command as the given user. Requires an admin login",
+}
+
+// OTPFlag provides a global flag to pass a one-time password for two-factor authentication
+var OTPFlag = cli.StringFlag{
+	Name:    "otp",
+	EnvVars: []string{"TEA_OTP"},
+	Usage:   "One-time password for two-factor authentication, needed when authenticating with username & password",
+}
+
+// BasicAuthFlags provide flags to authenticate with username & password,
+// which is required by some endpoints instead of the token of a login
+var BasicAuthFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "user",
+		Usage:   "User for basic auth, defaults to the user of the login",
+		EnvVars: []string{"GITEA_SERVER_USER"},
+	},
+	&cli.StringFlag{
+		Name:    "password",
+		Aliases: []string{"pwd"},
+		Usage:   "Password for basic auth, prompted for if not given",
+		EnvVars: []string{"GITEA_SERVER_PASSWORD"},
+	},
+}
+
 // RepoFlag provides flag to specify repository
 var RepoFlag = cli.StringFlag{
 	Name:    "repo",
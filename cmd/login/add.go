@@ -5,6 +5,9 @@
 package login
 
 import (
+	"fmt"
+
+	"code.gitea.io/tea/modules/config"
 	"code.gitea.io/tea/modules/interact"
 	"code.gitea.io/tea/modules/task"
 
@@ -13,9 +16,11 @@ import (
 
 // CmdLoginAdd represents to login a gitea server.
 var CmdLoginAdd = cli.Command{
-	Name:        "add",
-	Usage:       "Add a Gitea login",
-	Description: `Add a Gitea login, without args it will create one interactively`,
+	Name:  "add",
+	Usage: "Add a Gitea login",
+	Description: `Add a Gitea login, without args it will create one interactively.
+When run in a terminal, it offers to add a local SSH and GPG key to your
+account, if the server doesn't know them yet.`,
 	Flags: []cli.Flag{
 		&cli.StringFlag{
 			Name:    "name",
@@ -49,6 +54,10 @@ var CmdLoginAdd = cli.Command{
 			EnvVars: []string{"GITEA_SERVER_PASSWORD"},
 			Usage:   "Password for basic auth (will create token)",
 		},
+		&cli.StringFlag{
+			Name:  "otp",
+			Usage: "One-time password for basic auth, if two-factor authentication is enabled",
+		},
 		&cli.StringFlag{
 			Name:    "ssh-key",
 			Aliases: []string{"s"},
@@ -70,12 +79,35 @@ func runLoginAdd(ctx *cli.Context) error {
 	}
 
 	// else use args to add login
-	return task.CreateLogin(
-		ctx.String("name"),
-		ctx.String("token"),
-		ctx.String("user"),
-		ctx.String("password"),
-		ctx.String("ssh-key"),
-		ctx.String("url"),
-		ctx.Bool("insecure"))
+	create := func(otp string) (*config.Login, error) {
+		return task.CreateLogin(
+			ctx.String("name"),
+			ctx.String("token"),
+			ctx.String("user"),
+			ctx.String("password"),
+			otp,
+			ctx.String("ssh-key"),
+			ctx.String("url"),
+			ctx.Bool("insecure"))
+	}
+
+	login, err := create(ctx.String("otp"))
+	if err == task.ErrOTPRequired && !interact.IsStdinPiped() {
+		var otp string
+		if otp, err = interact.PromptOTP(); err != nil {
+			return err
+		}
+		login, err = create(otp)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !interact.IsStdinPiped() {
+		return interact.SetupLoginKeys(login)
+	}
+	if len(login.SSHKey) == 0 {
+		fmt.Println("No SSH key found for this login, add one with 'tea me ssh-keys add'")
+	}
+	return nil
 }
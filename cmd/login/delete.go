@@ -6,21 +6,35 @@ package login
 
 import (
 	"errors"
+	"fmt"
 	"log"
+	"net/http"
 
+	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/interact"
 
 	"github.com/urfave/cli/v2"
 )
 
+// DeleteFlags are the flags of commands removing a login
+var DeleteFlags = append([]cli.Flag{
+	&cli.BoolFlag{
+		Name:  "revoke",
+		Usage: "Revoke the access token tea created for the login on the server, without asking. Keeps the login if revoking fails",
+	},
+}, flags.BasicAuthFlags...)
+
 // CmdLoginDelete is a command to delete a login
 var CmdLoginDelete = cli.Command{
-	Name:        "delete",
-	Aliases:     []string{"rm"},
-	Usage:       "Remove a Gitea login",
-	Description: `Remove a Gitea login`,
-	ArgsUsage:   "<login name>",
-	Action:      RunLoginDelete,
+	Name:    "delete",
+	Aliases: []string{"rm"},
+	Usage:   "Remove a Gitea login",
+	Description: `Remove a Gitea login. If tea created the access token of the login,
+it offers to revoke the token on the server, which requires your password.`,
+	ArgsUsage: "<login name>",
+	Action:    RunLoginDelete,
+	Flags:     DeleteFlags,
 }
 
 // RunLoginDelete runs the action of a login delete command
@@ -40,5 +54,53 @@ func RunLoginDelete(ctx *cli.Context) error {
 		return errors.New("Please specify a login name")
 	}
 
+	if login := config.GetLoginByName(name); login != nil && login.TokenID != 0 {
+		if err = revokeToken(ctx, login); err != nil {
+			// only keep the login if revoking was requested explicitly,
+			// so it can be retried
+			if ctx.Bool("revoke") {
+				return err
+			}
+			fmt.Printf("Warning: %s\n", err)
+		}
+	}
+
 	return config.DeleteLogin(name)
 }
+
+// revokeToken deletes the access token tea created for login, if requested
+func revokeToken(ctx *cli.Context, login *config.Login) error {
+	revoke := ctx.Bool("revoke")
+	if !revoke {
+		if interact.IsStdinPiped() {
+			fmt.Printf("The access token of login '%s' is still valid, use --revoke to revoke it\n", login.Name)
+			return nil
+		}
+		var err error
+		if revoke, err = interact.PromptConfirm("Revoke the access token of this login on the server?", true); err != nil {
+			return err
+		}
+		if !revoke {
+			return nil
+		}
+	}
+
+	user := ctx.String("user")
+	if len(user) == 0 {
+		user = login.User
+	}
+	login.OTP = ctx.String("otp")
+	client, err := interact.BasicAuthClient(login, user, ctx.String("password"))
+	if err != nil {
+		return fmt.Errorf("could not revoke access token: %s", err)
+	}
+	if resp, err := client.DeleteAccessToken(login.TokenID); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			fmt.Printf("Access token %d of login '%s' was already deleted\n", login.TokenID, login.Name)
+			return nil
+		}
+		return fmt.Errorf("could not revoke access token: %s", err)
+	}
+	fmt.Printf("Revoked access token %d of login '%s'\n", login.TokenID, login.Name)
+	return nil
+}
@@ -15,7 +15,8 @@ var CmdLogout = cli.Command{
 	Name:        "logout",
 	Category:    catSetup,
 	Usage:       "Log out from a Gitea server",
-	Description: `Log out from a Gitea server`,
+	Description: `Log out from a Gitea server, and revoke the access token tea created`,
 	ArgsUsage:   "<login name>",
 	Action:      login.RunLoginDelete,
+	Flags:       login.DeleteFlags,
 }
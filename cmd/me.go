@@ -0,0 +1,42 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/cmd/me"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdMe represents the command to manage the account of the current user
+var CmdMe = cli.Command{
+	Name:        "me",
+	Category:    catSetup,
+	Usage:       "Manage your account",
+	Description: "Shows your profile when called without sub command",
+	Action:      runMe,
+	Subcommands: []*cli.Command{
+		&me.CmdSettings,
+		&me.CmdEmails,
+		&me.CmdSSHKeys,
+		&me.CmdGPGKeys,
+	},
+	Flags: []cli.Flag{&flags.LoginFlag},
+}
+
+func runMe(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	user, _, err := ctx.Login.Client().GetMyUserInfo()
+	if err != nil {
+		return err
+	}
+
+	print.UserDetails(user)
+	return nil
+}
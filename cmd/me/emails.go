@@ -0,0 +1,93 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package me
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdEmails represents a sub command of me to manage email addresses
+var CmdEmails = cli.Command{
+	Name:        "emails",
+	Aliases:     []string{"email"},
+	Usage:       "Manage your email addresses",
+	Description: "Lists your email addresses when called without sub command",
+	Action:      runEmailsList,
+	Subcommands: []*cli.Command{
+		{
+			Name:        "list",
+			Aliases:     []string{"ls"},
+			Usage:       "List your email addresses",
+			Description: "List your email addresses",
+			Action:      runEmailsList,
+			Flags:       flags.LoginOutputFlags,
+		},
+		{
+			Name:        "add",
+			Aliases:     []string{"a"},
+			Usage:       "Add email addresses",
+			Description: "Add email addresses to your account",
+			ArgsUsage:   "<email> [<email>...]",
+			Action:      runEmailsAdd,
+			Flags:       flags.LoginOutputFlags,
+		},
+		{
+			Name:        "remove",
+			Aliases:     []string{"rm"},
+			Usage:       "Remove email addresses",
+			Description: "Remove email addresses from your account",
+			ArgsUsage:   "<email> [<email>...]",
+			Action:      runEmailsRemove,
+			Flags:       []cli.Flag{&flags.LoginFlag},
+		},
+	},
+	Flags: flags.LoginOutputFlags,
+}
+
+func runEmailsList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	emails, _, err := ctx.Login.Client().ListEmails(gitea.ListEmailsOptions{})
+	if err != nil {
+		return err
+	}
+
+	print.EmailsList(emails, ctx.Output)
+	return nil
+}
+
+func runEmailsAdd(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if !ctx.Args().Present() {
+		return fmt.Errorf("Must specify at least one email address")
+	}
+
+	emails, _, err := ctx.Login.Client().AddEmail(gitea.CreateEmailOption{Emails: ctx.Args().Slice()})
+	if err != nil {
+		return err
+	}
+
+	print.EmailsList(emails, ctx.Output)
+	return nil
+}
+
+func runEmailsRemove(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if !ctx.Args().Present() {
+		return fmt.Errorf("Must specify at least one email address")
+	}
+
+	_, err := ctx.Login.Client().DeleteEmail(gitea.DeleteEmailOption{Emails: ctx.Args().Slice()})
+	return err
+}
@@ -0,0 +1,161 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package me
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdGPGKeys represents a sub command of me to manage GPG keys
+var CmdGPGKeys = cli.Command{
+	Name:        "gpg-keys",
+	Aliases:     []string{"gpg-key", "gpg"},
+	Usage:       "Manage your GPG keys",
+	Description: "Lists your GPG keys when called without sub command",
+	Action:      runGPGKeysList,
+	Subcommands: []*cli.Command{
+		&CmdGPGKeysList,
+		&CmdGPGKeysAdd,
+		&CmdGPGKeysRemove,
+	},
+	Flags: flags.LoginOutputFlags,
+}
+
+// CmdGPGKeysList represents a sub command of gpg-keys to list them
+var CmdGPGKeysList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List your GPG keys",
+	Description: "List your GPG keys",
+	Action:      runGPGKeysList,
+	Flags:       flags.LoginOutputFlags,
+}
+
+// CmdGPGKeysAdd represents a sub command of gpg-keys to add one
+var CmdGPGKeysAdd = cli.Command{
+	Name:    "add",
+	Aliases: []string{"a"},
+	Usage:   "Add a GPG key",
+	Description: `Add an ASCII armored GPG public key, read from a file or stdin, e.g.
+	gpg --armor --export <key id> | tea me gpg-keys add -`,
+	ArgsUsage: "<key file | ->",
+	Action:    runGPGKeysAdd,
+	Flags:     flags.LoginOutputFlags,
+}
+
+// CmdGPGKeysRemove represents a sub command of gpg-keys to remove them
+var CmdGPGKeysRemove = cli.Command{
+	Name:        "remove",
+	Aliases:     []string{"rm"},
+	Usage:       "Remove GPG keys",
+	Description: "Remove GPG keys, by their ID or key ID",
+	ArgsUsage:   "<id | key id> [<id | key id>...]",
+	Action:      runGPGKeysRemove,
+	Flags:       []cli.Flag{&flags.LoginFlag},
+}
+
+func runGPGKeysList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	keys, _, err := ctx.Login.Client().ListMyGPGKeys(&gitea.ListGPGKeysOptions{})
+	if err != nil {
+		return err
+	}
+
+	print.GPGKeysList(keys, ctx.Output)
+	return nil
+}
+
+func runGPGKeysAdd(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a key file, or - to read from stdin")
+	}
+
+	var (
+		content []byte
+		err     error
+	)
+	if path := ctx.Args().First(); path == "-" {
+		content, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		content, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	key, _, err := ctx.Login.Client().CreateGPGKey(gitea.CreateGPGKeyOption{ArmoredKey: string(content)})
+	if err != nil {
+		return err
+	}
+
+	print.GPGKeysList([]*gitea.GPGKey{key}, ctx.Output)
+	return nil
+}
+
+func runGPGKeysRemove(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if !ctx.Args().Present() {
+		return fmt.Errorf("Must specify at least one id or key id")
+	}
+
+	var keys []*gitea.GPGKey
+	for _, arg := range ctx.Args().Slice() {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			// not an ID, so look up the key by its key ID
+			if keys == nil {
+				if keys, err = listAllGPGKeys(client); err != nil {
+					return err
+				}
+			}
+			for _, k := range keys {
+				if k.KeyID == arg {
+					id = k.ID
+				}
+			}
+			if id == 0 {
+				return fmt.Errorf("no GPG key with key id %s found", arg)
+			}
+		}
+
+		if _, err = client.DeleteGPGKey(id); err != nil {
+			return fmt.Errorf("could not remove GPG key %s: %s", arg, err)
+		}
+		fmt.Printf("Removed GPG key %s\n", arg)
+	}
+	return nil
+}
+
+// listAllGPGKeys fetches all GPG keys of the user, iterating over all pages
+func listAllGPGKeys(client *gitea.Client) ([]*gitea.GPGKey, error) {
+	var keys []*gitea.GPGKey
+	for page := 1; ; page++ {
+		batch, _, err := client.ListMyGPGKeys(&gitea.ListGPGKeysOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: 50},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			return keys, nil
+		}
+		keys = append(keys, batch...)
+	}
+}
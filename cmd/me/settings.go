@@ -0,0 +1,110 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package me
+
+import (
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdSettings represents a sub command of me to show and change account settings
+var CmdSettings = cli.Command{
+	Name:        "settings",
+	Aliases:     []string{"s"},
+	Usage:       "Show or change your settings",
+	Description: "Shows your settings, or changes the ones given as flags",
+	Action:      runSettings,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "full-name",
+			Usage: "your full name",
+		},
+		&cli.StringFlag{
+			Name:  "description",
+			Usage: "your profile description",
+		},
+		&cli.StringFlag{
+			Name:  "website",
+			Usage: "your website",
+		},
+		&cli.StringFlag{
+			Name:  "location",
+			Usage: "your location",
+		},
+		&cli.StringFlag{
+			Name:  "language",
+			Usage: "language of the web interface, e.g. en-US",
+		},
+		&cli.StringFlag{
+			Name:  "theme",
+			Usage: "theme of the web interface",
+		},
+		&cli.StringFlag{
+			Name:  "diff-view-style",
+			Usage: "unified or split",
+		},
+		&cli.BoolFlag{
+			Name:  "hide-email",
+			Usage: "hide your email address from other users",
+		},
+		&cli.BoolFlag{
+			Name:  "hide-activity",
+			Usage: "hide your activity from your profile page",
+		},
+		&flags.LoginFlag,
+	},
+}
+
+func runSettings(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	var opts gitea.UserSettingsOptions
+	changed := false
+	stringOption := func(flag string) *string {
+		if !ctx.IsSet(flag) {
+			return nil
+		}
+		changed = true
+		value := ctx.String(flag)
+		return &value
+	}
+	boolOption := func(flag string) *bool {
+		if !ctx.IsSet(flag) {
+			return nil
+		}
+		changed = true
+		return gitea.OptionalBool(ctx.Bool(flag))
+	}
+	opts.FullName = stringOption("full-name")
+	opts.Description = stringOption("description")
+	opts.Website = stringOption("website")
+	opts.Location = stringOption("location")
+	opts.Language = stringOption("language")
+	opts.Theme = stringOption("theme")
+	opts.DiffViewStyle = stringOption("diff-view-style")
+	opts.HideEmail = boolOption("hide-email")
+	opts.HideActivity = boolOption("hide-activity")
+
+	var (
+		settings *gitea.UserSettings
+		err      error
+	)
+	if changed {
+		settings, _, err = client.UpdateUserSettings(opts)
+	} else {
+		settings, _, err = client.GetUserSettings()
+	}
+	if err != nil {
+		return err
+	}
+
+	print.UserSettingsDetails(settings)
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package me
+
+import (
+	"fmt"
+	"strconv"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/crypto/ssh"
+)
+
+var sshKeysListFlags = append([]cli.Flag{
+	&flags.PaginationPageFlag,
+	&flags.PaginationLimitFlag,
+}, flags.LoginOutputFlags...)
+
+// CmdSSHKeys represents a sub command of me to manage SSH keys
+var CmdSSHKeys = cli.Command{
+	Name:        "ssh-keys",
+	Aliases:     []string{"ssh-key", "keys"},
+	Usage:       "Manage your SSH keys",
+	Description: "Lists your SSH keys when called without sub command",
+	Action:      runSSHKeysList,
+	Subcommands: []*cli.Command{
+		&CmdSSHKeysList,
+		&CmdSSHKeysAdd,
+		&CmdSSHKeysRemove,
+	},
+	Flags: sshKeysListFlags,
+}
+
+// CmdSSHKeysList represents a sub command of ssh-keys to list them
+var CmdSSHKeysList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List your SSH keys",
+	Description: "List your SSH keys",
+	Action:      runSSHKeysList,
+	Flags:       sshKeysListFlags,
+}
+
+// CmdSSHKeysAdd represents a sub command of ssh-keys to add one
+var CmdSSHKeysAdd = cli.Command{
+	Name:    "add",
+	Aliases: []string{"a"},
+	Usage:   "Add a SSH key",
+	Description: `Add a SSH key, either by reading a public key from a file, or
+by generating a new ed25519 keypair, which is stored locally.`,
+	ArgsUsage: "<title>",
+	Action:    runSSHKeysAdd,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "key-file",
+			Aliases: []string{"k"},
+			Usage:   "public key file to add",
+		},
+		&cli.StringFlag{
+			Name:    "generate",
+			Aliases: []string{"g"},
+			Usage:   "generate a new keypair, and store the private key at the given path",
+		},
+		&flags.LoginFlag,
+	},
+}
+
+// CmdSSHKeysRemove represents a sub command of ssh-keys to remove them
+var CmdSSHKeysRemove = cli.Command{
+	Name:        "remove",
+	Aliases:     []string{"rm"},
+	Usage:       "Remove SSH keys",
+	Description: "Remove SSH keys, by their ID or fingerprint",
+	ArgsUsage:   "<key id | fingerprint> [<key id | fingerprint>...]",
+	Action:      runSSHKeysRemove,
+	Flags:       []cli.Flag{&flags.LoginFlag},
+}
+
+func runSSHKeysList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	keys, _, err := ctx.Login.Client().ListMyPublicKeys(gitea.ListPublicKeysOptions{
+		ListOptions: ctx.GetListOptions(),
+	})
+	if err != nil {
+		return err
+	}
+
+	print.PublicKeysList(keys, ctx.Output)
+	return nil
+}
+
+func runSSHKeysAdd(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a title")
+	}
+	title := ctx.Args().First()
+
+	var (
+		key     ssh.PublicKey
+		content string
+		err     error
+	)
+	switch {
+	case ctx.IsSet("key-file") && ctx.IsSet("generate"):
+		return fmt.Errorf("--key-file and --generate are mutually exclusive")
+	case ctx.IsSet("key-file"):
+		key, content, err = task.ReadSSHPublicKey(ctx.String("key-file"))
+	case ctx.IsSet("generate"):
+		var path string
+		if path, err = utils.AbsPathWithExpansion(ctx.String("generate")); err != nil {
+			return err
+		}
+		key, content, err = task.GenerateSSHKey(path, title)
+		if err == nil {
+			fmt.Printf("Stored private key at %s\n", path)
+		}
+	default:
+		return fmt.Errorf("Must specify either --key-file or --generate")
+	}
+	if err != nil {
+		return err
+	}
+
+	k, _, err := ctx.Login.Client().CreatePublicKey(gitea.CreateKeyOption{
+		Title: title,
+		Key:   content,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Added SSH key %d: %s\n", k.ID, ssh.FingerprintSHA256(key))
+	return nil
+}
+
+func runSSHKeysRemove(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if !ctx.Args().Present() {
+		return fmt.Errorf("Must specify at least one key id or fingerprint")
+	}
+
+	var keys []*gitea.PublicKey
+	for _, arg := range ctx.Args().Slice() {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			// not an ID, so look up the key by its fingerprint
+			if keys == nil {
+				if keys, err = listAllPublicKeys(client); err != nil {
+					return err
+				}
+			}
+			for _, k := range keys {
+				if k.Fingerprint == arg {
+					id = k.ID
+				}
+			}
+			if id == 0 {
+				return fmt.Errorf("no SSH key with fingerprint %s found", arg)
+			}
+		}
+
+		if _, err = client.DeletePublicKey(id); err != nil {
+			return fmt.Errorf("could not remove SSH key %s: %s", arg, err)
+		}
+		fmt.Printf("Removed SSH key %s\n", arg)
+	}
+	return nil
+}
+
+// listAllPublicKeys fetches all SSH keys of the user, iterating over all pages
+func listAllPublicKeys(client *gitea.Client) ([]*gitea.PublicKey, error) {
+	var keys []*gitea.PublicKey
+	for page := 1; ; page++ {
+		batch, _, err := client.ListMyPublicKeys(gitea.ListPublicKeysOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: 50},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			return keys, nil
+		}
+		keys = append(keys, batch...)
+	}
+}
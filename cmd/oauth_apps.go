@@ -0,0 +1,55 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"code.gitea.io/tea/cmd/oauthapps"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdOauthApps represents the command to manage oauth2 applications
+var CmdOauthApps = cli.Command{
+	Name:        "oauth-apps",
+	Aliases:     []string{"oauth-app", "oauth"},
+	Category:    catSetup,
+	Usage:       "Manage your OAuth2 applications",
+	Description: "Lists your OAuth2 applications when called without argument. If an application ID is provided, will show it in detail.",
+	ArgsUsage:   "[<application id>]",
+	Action:      runOauthApps,
+	Subcommands: []*cli.Command{
+		&oauthapps.CmdOauthAppsList,
+		&oauthapps.CmdOauthAppsCreate,
+		&oauthapps.CmdOauthAppsEdit,
+		&oauthapps.CmdOauthAppsDelete,
+	},
+	Flags: oauthapps.CmdOauthAppsList.Flags,
+}
+
+func runOauthApps(cmd *cli.Context) error {
+	if cmd.Args().Len() == 1 {
+		return runOauthAppDetail(cmd, cmd.Args().First())
+	}
+	return oauthapps.RunOauthAppsList(cmd)
+}
+
+func runOauthAppDetail(cmd *cli.Context, arg string) error {
+	ctx := context.InitCommand(cmd)
+	id, err := utils.ArgToIndex(arg)
+	if err != nil {
+		return err
+	}
+
+	app, _, err := ctx.Login.Client().GetOauth2(id)
+	if err != nil {
+		return err
+	}
+
+	print.OauthAppDetails(app)
+	return nil
+}
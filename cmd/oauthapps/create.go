@@ -0,0 +1,67 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package oauthapps
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+var outputFlag = cli.StringFlag{
+	Name:    "output",
+	Aliases: []string{"o"},
+	Usage:   "Output format of the credentials. (env, csv, simple, table, tsv, yaml)",
+}
+
+var redirectURIFlag = cli.StringSliceFlag{
+	Name:    "redirect-uri",
+	Aliases: []string{"r"},
+	Usage:   "URI to redirect to after authorization. Can be repeated",
+}
+
+// CmdOauthAppsCreate represents a sub command of oauth-apps to create one
+var CmdOauthAppsCreate = cli.Command{
+	Name:    "create",
+	Aliases: []string{"c"},
+	Usage:   "Create an OAuth2 application",
+	Description: `Create an OAuth2 application. Its client ID and secret are printed as
+KEY=value lines, which can be used as env file, or in the format given by --output.
+The client secret is only shown once, so make sure to store it.`,
+	ArgsUsage: "<name>",
+	Action:    runOauthAppsCreate,
+	Flags: append([]cli.Flag{
+		&redirectURIFlag,
+		&outputFlag,
+	}, &flags.LoginFlag),
+}
+
+func runOauthAppsCreate(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a name for the application")
+	}
+	uris := ctx.StringSlice("redirect-uri")
+	if len(uris) == 0 {
+		return fmt.Errorf("Must specify at least one --redirect-uri")
+	}
+
+	app, _, err := ctx.Login.Client().CreateOauth2(gitea.CreateOauth2Option{
+		Name:         ctx.Args().First(),
+		RedirectURIs: uris,
+	})
+	if err != nil {
+		return err
+	}
+
+	print.OauthAppCredentials(app, ctx.Output)
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package oauthapps
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdOauthAppsDelete represents a sub command of oauth-apps to delete them
+var CmdOauthAppsDelete = cli.Command{
+	Name:        "delete",
+	Aliases:     []string{"rm"},
+	Usage:       "Delete OAuth2 applications",
+	Description: "Delete OAuth2 applications. Clients using them can't authorize users anymore",
+	ArgsUsage:   "<application id> [<application id>...]",
+	Action:      runOauthAppsDelete,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "confirm",
+			Aliases: []string{"y"},
+			Usage:   "Confirm deletion (required)",
+		},
+		&flags.LoginFlag,
+	},
+}
+
+func runOauthAppsDelete(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if !ctx.Args().Present() {
+		return fmt.Errorf("Must specify at least one application id")
+	}
+
+	if !ctx.Bool("confirm") {
+		fmt.Println("Are you sure? Please confirm with -y or --confirm.")
+		return nil
+	}
+
+	client := ctx.Login.Client()
+	for _, arg := range ctx.Args().Slice() {
+		id, err := utils.ArgToIndex(arg)
+		if err != nil {
+			return err
+		}
+		if _, err = client.DeleteOauth2(id); err != nil {
+			return fmt.Errorf("could not delete application %d: %s", id, err)
+		}
+		fmt.Printf("Deleted OAuth2 application %d\n", id)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package oauthapps
+
+import (
+	"fmt"
+	"os"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdOauthAppsEdit represents a sub command of oauth-apps to edit one
+var CmdOauthAppsEdit = cli.Command{
+	Name:    "edit",
+	Aliases: []string{"e"},
+	Usage:   "Edit an OAuth2 application",
+	Description: `Edit an OAuth2 application. Only the settings given as flags are changed,
+--redirect-uri replaces all redirect URIs.
+The server generates a new client secret on every edit, so the old one stops
+working. The new credentials are printed like by 'create', in the format given
+by --output.`,
+	ArgsUsage: "<application id>",
+	Action:    runOauthAppsEdit,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "name",
+			Aliases: []string{"n"},
+			Usage:   "new name of the application",
+		},
+		&redirectURIFlag,
+		&outputFlag,
+		&flags.LoginFlag,
+	},
+}
+
+func runOauthAppsEdit(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify an application id")
+	}
+	id, err := utils.ArgToIndex(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	// the API replaces all settings, so start from the current ones
+	app, _, err := client.GetOauth2(id)
+	if err != nil {
+		return err
+	}
+	opts := gitea.CreateOauth2Option{
+		Name:         app.Name,
+		RedirectURIs: app.RedirectURIs,
+	}
+	if ctx.IsSet("name") {
+		opts.Name = ctx.String("name")
+	}
+	if ctx.IsSet("redirect-uri") {
+		opts.RedirectURIs = ctx.StringSlice("redirect-uri")
+	}
+
+	if app, _, err = client.UpdateOauth2(id, opts); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Warning: the client secret of '%s' was regenerated, the old one doesn't work anymore\n", app.Name)
+	print.OauthAppCredentials(app, ctx.Output)
+	return nil
+}
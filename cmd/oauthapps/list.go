@@ -0,0 +1,42 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package oauthapps
+
+import (
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdOauthAppsList represents a sub command of oauth-apps to list them
+var CmdOauthAppsList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List OAuth2 applications",
+	Description: "List your OAuth2 applications",
+	Action:      RunOauthAppsList,
+	Flags: append([]cli.Flag{
+		&flags.PaginationPageFlag,
+		&flags.PaginationLimitFlag,
+	}, flags.LoginOutputFlags...),
+}
+
+// RunOauthAppsList lists the oauth2 applications of the user
+func RunOauthAppsList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	apps, _, err := ctx.Login.Client().ListOauth2(gitea.ListOauth2Option{
+		ListOptions: ctx.GetListOptions(),
+	})
+	if err != nil {
+		return err
+	}
+
+	print.OauthAppsList(apps, ctx.Output)
+	return nil
+}
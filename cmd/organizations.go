@@ -5,9 +5,12 @@
 package cmd
 
 import (
+	"net/http"
+
 	"code.gitea.io/tea/cmd/organizations"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
 
 	"github.com/urfave/cli/v2"
 )
@@ -17,14 +20,19 @@ var CmdOrgs = cli.Command{
 	Name:        "organizations",
 	Aliases:     []string{"organization", "org"},
 	Category:    catEntities,
-	Usage:       "List, create, delete organizations",
+	Usage:       "Manage organizations, their members and teams",
 	Description: "Show organization details",
 	ArgsUsage:   "[<organization>]",
 	Action:      runOrganizations,
 	Subcommands: []*cli.Command{
 		&organizations.CmdOrganizationList,
 		&organizations.CmdOrganizationCreate,
+		&organizations.CmdOrganizationEdit,
 		&organizations.CmdOrganizationDelete,
+		&organizations.CmdOrganizationMembers,
+		&organizations.CmdOrganizationTeams,
+		&organizations.CmdOrganizationApply,
+		&organizations.CmdOrganizationExport,
 	},
 	Flags: organizations.CmdOrganizationList.Flags,
 }
@@ -38,11 +46,22 @@ func runOrganizations(cmd *cli.Context) error {
 }
 
 func runOrganizationDetail(ctx *context.TeaContext) error {
-	org, _, err := ctx.Login.Client().GetOrg(ctx.Args().First())
+	client := ctx.Login.Client()
+	org, _, err := client.GetOrg(ctx.Args().First())
 	if err != nil {
 		return err
 	}
 
-	print.OrganizationDetails(org)
+	members, err := task.ListAllOrgMembers(client, org.UserName)
+	if err != nil {
+		return err
+	}
+	// teams are only visible to members, so we don't fail if access is denied
+	teams, resp, err := task.ListAllOrgTeams(client, org.UserName)
+	if err != nil && (resp == nil || (resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusNotFound)) {
+		return err
+	}
+
+	print.OrganizationDetails(org, members, teams)
 	return nil
 }
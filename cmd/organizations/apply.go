@@ -0,0 +1,99 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organizations
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/task"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdOrganizationApply represents a sub command of organizations to apply a declarative org state
+var CmdOrganizationApply = cli.Command{
+	Name:  "apply",
+	Usage: "Bring an organization to the state described in a YAML file",
+	Description: `Compares the settings, teams, team members, team repos, repo collaborators
+and repo labels described in a YAML file with the organization on the server,
+and prints the changes needed. With --yes the changes are applied.
+
+Teams not contained in the file are deleted, except for the owners team.
+Lists which are left out of the file (e.g. the members of a team) are not
+managed, while an empty list removes all entries on the server.
+The organization and its repos must exist already.
+
+Use 'tea orgs export' to get started:
+
+	name: myorg
+	settings:
+	  full_name: My Org
+	  description: ""
+	  website: ""
+	  location: ""
+	  visibility: public
+	teams:
+	  - name: devs
+	    description: ""
+	    permission: write
+	    units: [code, issues, pulls, releases, wiki, projects]
+	    can_create_org_repo: false
+	    includes_all_repositories: false
+	    members: [alice, bob]
+	    repos: [tea]
+	repos:
+	  - name: tea
+	    collaborators:
+	      - name: carol
+	        permission: read
+	    labels:
+	      - name: bug
+	        color: ee0701
+	        description: Something is not working`,
+	Action: runOrganizationApply,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "file",
+			Aliases:  []string{"f"},
+			Usage:    "YAML file describing the organization",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:    "yes",
+			Aliases: []string{"y"},
+			Usage:   "Apply the changes instead of only showing them",
+		},
+		&flags.LoginFlag,
+	},
+}
+
+func runOrganizationApply(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	state, err := task.ReadOrgState(ctx.String("file"))
+	if err != nil {
+		return err
+	}
+
+	plan, err := task.PlanOrgState(client, state)
+	if err != nil {
+		return err
+	}
+	plan.Print()
+
+	if len(plan.Changes) == 0 {
+		return nil
+	}
+	if !ctx.Bool("yes") {
+		fmt.Println("Run again with --yes to apply these changes.")
+		return nil
+	}
+
+	fmt.Println()
+	return plan.Apply()
+}
@@ -23,32 +23,37 @@ var CmdOrganizationCreate = cli.Command{
 	Description: "Create an organization",
 	Action:      RunOrganizationCreate,
 	ArgsUsage:   "<organization name>",
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		&cli.StringFlag{
 			Name:    "name",
 			Aliases: []string{"n"},
 		},
-		&cli.StringFlag{
-			Name:    "description",
-			Aliases: []string{"d"},
-		},
-		&cli.StringFlag{
-			Name:    "website",
-			Aliases: []string{"w"},
-		},
-		&cli.StringFlag{
-			Name:    "location",
-			Aliases: []string{"L"},
-		},
-		&cli.StringFlag{
-			Name:    "visibility",
-			Aliases: []string{"v"},
-		},
 		&cli.BoolFlag{
 			Name: "repo-admins-can-change-team-access",
 		},
-		&flags.LoginFlag,
+	}, orgSettingFlags...),
+}
+
+// orgSettingFlags are the flags shared by organization create and edit
+var orgSettingFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "description",
+		Aliases: []string{"d"},
 	},
+	&cli.StringFlag{
+		Name:    "website",
+		Aliases: []string{"w"},
+	},
+	&cli.StringFlag{
+		Name:    "location",
+		Aliases: []string{"L"},
+	},
+	&cli.StringFlag{
+		Name:    "visibility",
+		Aliases: []string{"v"},
+		Usage:   "public, limited or private",
+	},
+	&flags.LoginFlag,
 }
 
 // RunOrganizationCreate sets up a new organization
@@ -59,19 +64,29 @@ func RunOrganizationCreate(cmd *cli.Context) error {
 		return fmt.Errorf("You have to specify the organization name you want to create")
 	}
 
-	var visibility gitea.VisibleType
-	switch ctx.String("visibility") {
-	case "", "public":
-		visibility = gitea.VisibleTypePublic
-	case "private":
-		visibility = gitea.VisibleTypePrivate
-	case "limited":
-		visibility = gitea.VisibleTypeLimited
-	default:
-		return fmt.Errorf("unknown visibility '%s'", ctx.String("visibility"))
+	opts, err := GetCreateOrgOption(ctx)
+	if err != nil {
+		return err
 	}
 
-	org, _, err := ctx.Login.Client().CreateOrg(gitea.CreateOrgOption{
+	org, _, err := ctx.Login.Client().CreateOrg(*opts)
+	if err != nil {
+		return err
+	}
+
+	print.OrganizationDetails(org, nil, nil)
+
+	return err
+}
+
+// GetCreateOrgOption builds the options to create an organization from the flags of CmdOrganizationCreate
+func GetCreateOrgOption(ctx *context.TeaContext) (*gitea.CreateOrgOption, error) {
+	visibility, err := GetVisibility(ctx.String("visibility"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gitea.CreateOrgOption{
 		Name: ctx.Args().First(),
 		// FullName: , // not really meaningful for orgs (not displayed in webui, use description instead?)
 		Description:               ctx.String("description"),
@@ -79,12 +94,18 @@ func RunOrganizationCreate(cmd *cli.Context) error {
 		Location:                  ctx.String("location"),
 		RepoAdminChangeTeamAccess: ctx.Bool("repo-admins-can-change-team-access"),
 		Visibility:                visibility,
-	})
-	if err != nil {
-		return err
-	}
-
-	print.OrganizationDetails(org)
+	}, nil
+}
 
-	return err
+// GetVisibility parses the value of a --visibility flag, defaulting to public
+func GetVisibility(value string) (gitea.VisibleType, error) {
+	switch value {
+	case "", "public":
+		return gitea.VisibleTypePublic, nil
+	case "private":
+		return gitea.VisibleTypePrivate, nil
+	case "limited":
+		return gitea.VisibleTypeLimited, nil
+	}
+	return "", fmt.Errorf("unknown visibility '%s'", value)
 }
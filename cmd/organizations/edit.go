@@ -0,0 +1,82 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organizations
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdOrganizationEdit represents a sub command of organizations to edit one
+var CmdOrganizationEdit = cli.Command{
+	Name:        "edit",
+	Aliases:     []string{"e"},
+	Usage:       "Edit an organization",
+	Description: "Edit the settings of an organization. Only the settings given as flags are changed.",
+	ArgsUsage:   "<organization name>",
+	Action:      runOrganizationEdit,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "full-name",
+			Usage: "display name of the organization",
+		},
+	}, orgSettingFlags...),
+}
+
+func runOrganizationEdit(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("You have to specify the organization name you want to edit")
+	}
+	name := ctx.Args().First()
+
+	org, _, err := client.GetOrg(name)
+	if err != nil {
+		return err
+	}
+
+	// the API overwrites all fields, so we start with the current settings
+	opts := gitea.EditOrgOption{
+		FullName:    org.FullName,
+		Description: org.Description,
+		Website:     org.Website,
+		Location:    org.Location,
+		Visibility:  gitea.VisibleType(org.Visibility),
+	}
+	if ctx.IsSet("full-name") {
+		opts.FullName = ctx.String("full-name")
+	}
+	if ctx.IsSet("description") {
+		opts.Description = ctx.String("description")
+	}
+	if ctx.IsSet("website") {
+		opts.Website = ctx.String("website")
+	}
+	if ctx.IsSet("location") {
+		opts.Location = ctx.String("location")
+	}
+	if ctx.IsSet("visibility") {
+		if opts.Visibility, err = GetVisibility(ctx.String("visibility")); err != nil {
+			return err
+		}
+	}
+
+	if _, err = client.EditOrg(name, opts); err != nil {
+		return err
+	}
+
+	if org, _, err = client.GetOrg(name); err != nil {
+		return err
+	}
+	print.OrganizationDetails(org, nil, nil)
+	return nil
+}
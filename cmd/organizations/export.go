@@ -0,0 +1,49 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organizations
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/task"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdOrganizationExport represents a sub command of organizations to export its state as YAML
+var CmdOrganizationExport = cli.Command{
+	Name:  "export",
+	Usage: "Save the state of an organization as YAML file",
+	Description: `Saves settings, teams, team members, team repos, repo collaborators and
+repo labels of an organization in the format used by 'tea orgs apply'.
+As the API does not expose the permission of collaborators, it is not exported.`,
+	ArgsUsage: "<organization>",
+	Action:    runOrganizationExport,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "file to write to, '-' for stdout",
+			Value:   "-",
+		},
+		&flags.LoginFlag,
+	},
+}
+
+func runOrganizationExport(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("You have to specify the organization name")
+	}
+
+	state, err := task.ExportOrgState(ctx.Login.Client(), ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	return task.WriteOrgState(state, ctx.String("file"))
+}
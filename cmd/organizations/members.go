@@ -0,0 +1,169 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organizations
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdOrganizationMembers represents a sub command of organizations to manage their members
+var CmdOrganizationMembers = cli.Command{
+	Name:        "members",
+	Aliases:     []string{"member"},
+	Usage:       "Manage organization members",
+	Description: "List the members of an organization when called without sub command",
+	ArgsUsage:   "<organization>",
+	Action:      runOrganizationMembersList,
+	Subcommands: []*cli.Command{
+		&CmdOrganizationMembersList,
+		&CmdOrganizationMembersRemove,
+		&CmdOrganizationMembersPublicize,
+		&CmdOrganizationMembersConceal,
+	},
+	Flags: orgMembersListFlags,
+}
+
+var orgMemberFieldsFlag = flags.FieldsFlag(print.UserFields, []string{
+	"login", "full_name", "email",
+})
+
+var orgMembersListFlags = append([]cli.Flag{
+	orgMemberFieldsFlag,
+	&cli.BoolFlag{
+		Name:  "public",
+		Usage: "only list members who made their membership public",
+	},
+	&flags.PaginationPageFlag,
+	&flags.PaginationLimitFlag,
+}, flags.LoginOutputFlags...)
+
+// CmdOrganizationMembersList represents a sub command of members to list them
+var CmdOrganizationMembersList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List members of an organization",
+	Description: "List members of an organization",
+	ArgsUsage:   "<organization>",
+	Action:      runOrganizationMembersList,
+	Flags:       orgMembersListFlags,
+}
+
+// CmdOrganizationMembersRemove represents a sub command of members to remove users
+var CmdOrganizationMembersRemove = cli.Command{
+	Name:        "remove",
+	Aliases:     []string{"rm"},
+	Usage:       "Remove users from an organization",
+	Description: "Remove users from an organization, including all its teams",
+	ArgsUsage:   "<organization> <username> [<username>...]",
+	Action:      runOrganizationMembersRemove,
+	Flags:       []cli.Flag{&flags.LoginFlag},
+}
+
+// CmdOrganizationMembersPublicize represents a sub command of members to make a membership public
+var CmdOrganizationMembersPublicize = cli.Command{
+	Name:        "publicize",
+	Usage:       "Make a membership public",
+	Description: "Make the membership of a user in an organization visible to everyone. Defaults to your own membership.",
+	ArgsUsage:   "<organization> [<username>]",
+	Action: func(cmd *cli.Context) error {
+		return setOrgMembershipPublic(cmd, true)
+	},
+	Flags: []cli.Flag{&flags.LoginFlag},
+}
+
+// CmdOrganizationMembersConceal represents a sub command of members to hide a membership
+var CmdOrganizationMembersConceal = cli.Command{
+	Name:        "conceal",
+	Usage:       "Hide a membership",
+	Description: "Hide the membership of a user in an organization from non-members. Defaults to your own membership.",
+	ArgsUsage:   "<organization> [<username>]",
+	Action: func(cmd *cli.Context) error {
+		return setOrgMembershipPublic(cmd, false)
+	},
+	Flags: []cli.Flag{&flags.LoginFlag},
+}
+
+func runOrganizationMembersList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("You have to specify the organization name")
+	}
+
+	opts := gitea.ListOrgMembershipOption{ListOptions: ctx.GetListOptions()}
+	var members []*gitea.User
+	var err error
+	if ctx.Bool("public") {
+		members, _, err = client.ListPublicOrgMembership(ctx.Args().First(), opts)
+	} else {
+		members, _, err = client.ListOrgMembership(ctx.Args().First(), opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	fields, err := orgMemberFieldsFlag.GetValues(cmd)
+	if err != nil {
+		return err
+	}
+	print.UserList(members, ctx.Output, fields)
+	return nil
+}
+
+func runOrganizationMembersRemove(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if ctx.Args().Len() < 2 {
+		return fmt.Errorf("You have to specify the organization and at least one username")
+	}
+
+	org := ctx.Args().First()
+	for _, user := range ctx.Args().Slice()[1:] {
+		isMember, _, err := client.CheckOrgMembership(org, user)
+		if err != nil {
+			return err
+		}
+		if !isMember {
+			return fmt.Errorf("%s is not a member of %s", user, org)
+		}
+		if _, err = client.DeleteOrgMembership(org, user); err != nil {
+			return fmt.Errorf("could not remove %s from %s: %s", user, org, err)
+		}
+		fmt.Printf("Removed %s from %s\n", user, org)
+	}
+	return nil
+}
+
+func setOrgMembershipPublic(cmd *cli.Context, public bool) error {
+	ctx := context.InitCommand(cmd)
+
+	if ctx.Args().Len() < 1 || ctx.Args().Len() > 2 {
+		return fmt.Errorf("You have to specify the organization and optionally a username")
+	}
+
+	org, user := ctx.Args().First(), ctx.Login.User
+	if ctx.Args().Len() == 2 {
+		user = ctx.Args().Get(1)
+	}
+
+	if _, err := ctx.Login.Client().SetPublicOrgMembership(org, user, public); err != nil {
+		return err
+	}
+	if public {
+		fmt.Printf("Membership of %s in %s is now public\n", user, org)
+	} else {
+		fmt.Printf("Membership of %s in %s is now concealed\n", user, org)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organizations
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdTeamMembers represents a sub command of teams to manage their members
+var CmdTeamMembers = cli.Command{
+	Name:        "members",
+	Aliases:     []string{"member"},
+	Usage:       "Manage team members",
+	Description: "List the members of a team when called without sub command",
+	ArgsUsage:   "<organization> <team>",
+	Action:      runTeamMembersList,
+	Subcommands: []*cli.Command{
+		{
+			Name:        "add",
+			Aliases:     []string{"a"},
+			Usage:       "Add users to a team",
+			Description: "Add users to a team",
+			ArgsUsage:   "<organization> <team> <username> [<username>...]",
+			Action: func(cmd *cli.Context) error {
+				return editTeamMembers(cmd, "Added %s to %s\n", func(client *gitea.Client, id int64, user string) error {
+					_, err := client.AddTeamMember(id, user)
+					return err
+				})
+			},
+			Flags: []cli.Flag{&flags.LoginFlag},
+		},
+		{
+			Name:        "remove",
+			Aliases:     []string{"rm"},
+			Usage:       "Remove users from a team",
+			Description: "Remove users from a team",
+			ArgsUsage:   "<organization> <team> <username> [<username>...]",
+			Action: func(cmd *cli.Context) error {
+				return editTeamMembers(cmd, "Removed %s from %s\n", func(client *gitea.Client, id int64, user string) error {
+					_, err := client.RemoveTeamMember(id, user)
+					return err
+				})
+			},
+			Flags: []cli.Flag{&flags.LoginFlag},
+		},
+	},
+	Flags: append([]cli.Flag{
+		teamMemberFieldsFlag,
+		&flags.PaginationPageFlag,
+		&flags.PaginationLimitFlag,
+	}, flags.LoginOutputFlags...),
+}
+
+var teamMemberFieldsFlag = flags.FieldsFlag(print.UserFields, []string{
+	"login", "full_name", "email",
+})
+
+func runTeamMembersList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if ctx.Args().Len() != 2 {
+		return fmt.Errorf("You have to specify the organization and team name")
+	}
+
+	team, err := task.FindTeam(client, ctx.Args().Get(0), ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	members, _, err := client.ListTeamMembers(team.ID, gitea.ListTeamMembersOptions{ListOptions: ctx.GetListOptions()})
+	if err != nil {
+		return err
+	}
+
+	fields, err := teamMemberFieldsFlag.GetValues(cmd)
+	if err != nil {
+		return err
+	}
+	print.UserList(members, ctx.Output, fields)
+	return nil
+}
+
+func editTeamMembers(cmd *cli.Context, message string, edit func(client *gitea.Client, id int64, user string) error) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if ctx.Args().Len() < 3 {
+		return fmt.Errorf("You have to specify the organization, team and at least one username")
+	}
+
+	team, err := task.FindTeam(client, ctx.Args().Get(0), ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	for _, user := range ctx.Args().Slice()[2:] {
+		if err = edit(client, team.ID, user); err != nil {
+			return fmt.Errorf("%s: %s", user, err)
+		}
+		fmt.Printf(message, user, team.Name)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organizations
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdTeamRepos represents a sub command of teams to manage their repos
+var CmdTeamRepos = cli.Command{
+	Name:        "repos",
+	Aliases:     []string{"repo"},
+	Usage:       "Manage team repos",
+	Description: "List the repos of a team when called without sub command",
+	ArgsUsage:   "<organization> <team>",
+	Action:      runTeamReposList,
+	Subcommands: []*cli.Command{
+		{
+			Name:        "add",
+			Aliases:     []string{"a"},
+			Usage:       "Give a team access to repos",
+			Description: "Give a team access to repos of the organization",
+			ArgsUsage:   "<organization> <team> <repo> [<repo>...]",
+			Action: func(cmd *cli.Context) error {
+				return editTeamRepos(cmd, "Added %s to %s\n", func(client *gitea.Client, id int64, org, repo string) error {
+					_, err := client.AddTeamRepository(id, org, repo)
+					return err
+				})
+			},
+			Flags: []cli.Flag{&flags.LoginFlag},
+		},
+		{
+			Name:        "remove",
+			Aliases:     []string{"rm"},
+			Usage:       "Remove access of a team to repos",
+			Description: "Remove access of a team to repos of the organization",
+			ArgsUsage:   "<organization> <team> <repo> [<repo>...]",
+			Action: func(cmd *cli.Context) error {
+				return editTeamRepos(cmd, "Removed %s from %s\n", func(client *gitea.Client, id int64, org, repo string) error {
+					_, err := client.RemoveTeamRepository(id, org, repo)
+					return err
+				})
+			},
+			Flags: []cli.Flag{&flags.LoginFlag},
+		},
+	},
+	Flags: append([]cli.Flag{
+		teamRepoFieldsFlag,
+		&flags.PaginationPageFlag,
+		&flags.PaginationLimitFlag,
+	}, flags.LoginOutputFlags...),
+}
+
+var teamRepoFieldsFlag = flags.FieldsFlag(print.RepoFields, []string{
+	"owner", "name", "type", "ssh",
+})
+
+func runTeamReposList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if ctx.Args().Len() != 2 {
+		return fmt.Errorf("You have to specify the organization and team name")
+	}
+
+	team, err := task.FindTeam(client, ctx.Args().Get(0), ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	repos, _, err := client.ListTeamRepositories(team.ID, gitea.ListTeamRepositoriesOptions{ListOptions: ctx.GetListOptions()})
+	if err != nil {
+		return err
+	}
+
+	fields, err := teamRepoFieldsFlag.GetValues(cmd)
+	if err != nil {
+		return err
+	}
+	print.ReposList(repos, ctx.Output, fields)
+	return nil
+}
+
+func editTeamRepos(cmd *cli.Context, message string, edit func(client *gitea.Client, id int64, org, repo string) error) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if ctx.Args().Len() < 3 {
+		return fmt.Errorf("You have to specify the organization, team and at least one repo")
+	}
+
+	org := ctx.Args().Get(0)
+	team, err := task.FindTeam(client, org, ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	for _, repo := range ctx.Args().Slice()[2:] {
+		if err = edit(client, team.ID, org, repo); err != nil {
+			return fmt.Errorf("%s: %s", repo, err)
+		}
+		fmt.Printf(message, repo, team.Name)
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organizations
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+var teamUnits = []string{"code", "issues", "pulls", "releases", "wiki", "ext_issues", "ext_wiki", "projects"}
+
+var teamUnitsFlag = flags.NewCsvFlag("units", "repo units the team has access to", nil, teamUnits, nil)
+
+var teamFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "description",
+		Aliases: []string{"d"},
+		Usage:   "description of the team",
+	},
+	&cli.StringFlag{
+		Name:    "permission",
+		Aliases: []string{"p"},
+		Usage:   "permission of the team on its repos (read, write, admin)",
+	},
+	teamUnitsFlag,
+	&cli.BoolFlag{
+		Name:  "all-repos",
+		Usage: "give the team access to all repos of the organization",
+	},
+	&cli.BoolFlag{
+		Name:  "can-create-repos",
+		Usage: "allow team members to create repos in the organization",
+	},
+	&flags.LoginFlag,
+}
+
+// CmdOrganizationTeams represents a sub command of organizations to manage teams
+var CmdOrganizationTeams = cli.Command{
+	Name:        "teams",
+	Aliases:     []string{"team"},
+	Usage:       "Manage organization teams",
+	Description: "Lists the teams of an organization when called with an organization name. If also a team name is provided, will show it in detail.",
+	ArgsUsage:   "<organization> [<team>]",
+	Action:      runTeams,
+	Subcommands: []*cli.Command{
+		&CmdTeamsList,
+		&CmdTeamsCreate,
+		&CmdTeamsEdit,
+		&CmdTeamsDelete,
+		&CmdTeamMembers,
+		&CmdTeamRepos,
+	},
+	Flags: flags.LoginOutputFlags,
+}
+
+// CmdTeamsList represents a sub command of teams to list them
+var CmdTeamsList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List teams of an organization",
+	Description: "List teams of an organization",
+	ArgsUsage:   "<organization>",
+	Action:      runTeamsList,
+	Flags:       flags.LoginOutputFlags,
+}
+
+// CmdTeamsCreate represents a sub command of teams to create one
+var CmdTeamsCreate = cli.Command{
+	Name:        "create",
+	Aliases:     []string{"c"},
+	Usage:       "Create a team",
+	Description: "Create a team in an organization",
+	ArgsUsage:   "<organization> <team>",
+	Action:      runTeamsCreate,
+	Flags:       teamFlags,
+}
+
+// CmdTeamsEdit represents a sub command of teams to edit one
+var CmdTeamsEdit = cli.Command{
+	Name:        "edit",
+	Aliases:     []string{"e"},
+	Usage:       "Edit a team",
+	Description: "Edit a team of an organization. Only the settings given as flags are changed.",
+	ArgsUsage:   "<organization> <team>",
+	Action:      runTeamsEdit,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "name",
+			Usage: "rename the team",
+		},
+	}, teamFlags...),
+}
+
+// CmdTeamsDelete represents a sub command of teams to delete one
+var CmdTeamsDelete = cli.Command{
+	Name:        "delete",
+	Aliases:     []string{"rm"},
+	Usage:       "Delete a team",
+	Description: "Delete a team of an organization",
+	ArgsUsage:   "<organization> <team>",
+	Action:      runTeamsDelete,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "confirm",
+			Aliases: []string{"y"},
+			Usage:   "Confirm deletion (required)",
+		},
+		&flags.LoginFlag,
+	},
+}
+
+func runTeams(cmd *cli.Context) error {
+	if cmd.Args().Len() == 2 {
+		return runTeamDetail(cmd)
+	}
+	return runTeamsList(cmd)
+}
+
+func runTeamDetail(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	team, err := task.FindTeam(client, ctx.Args().Get(0), ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	members, _, err := client.ListTeamMembers(team.ID, gitea.ListTeamMembersOptions{})
+	if err != nil {
+		return err
+	}
+	var repos []*gitea.Repository
+	if !team.IncludesAllRepositories {
+		if repos, _, err = client.ListTeamRepositories(team.ID, gitea.ListTeamRepositoriesOptions{}); err != nil {
+			return err
+		}
+	}
+
+	print.TeamDetails(team, members, repos)
+	return nil
+}
+
+func runTeamsList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if ctx.Args().Len() < 1 {
+		return fmt.Errorf("You have to specify the organization name")
+	}
+
+	teams, _, err := task.ListAllOrgTeams(ctx.Login.Client(), ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	print.TeamsList(teams, ctx.Output)
+	return nil
+}
+
+func runTeamsCreate(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if ctx.Args().Len() != 2 {
+		return fmt.Errorf("You have to specify the organization and team name")
+	}
+
+	permission, err := getTeamPermission(ctx, gitea.AccessModeRead)
+	if err != nil {
+		return err
+	}
+	units, err := getTeamUnits(ctx, []gitea.RepoUnitType{
+		gitea.RepoUnitCode,
+		gitea.RepoUnitIssues,
+		gitea.RepoUnitPulls,
+		gitea.RepoUnitReleases,
+		gitea.RepoUnitWiki,
+		gitea.RepoUnitProjects,
+	})
+	if err != nil {
+		return err
+	}
+
+	team, _, err := ctx.Login.Client().CreateTeam(ctx.Args().Get(0), gitea.CreateTeamOption{
+		Name:                    ctx.Args().Get(1),
+		Description:             ctx.String("description"),
+		Permission:              permission,
+		CanCreateOrgRepo:        ctx.Bool("can-create-repos"),
+		IncludesAllRepositories: ctx.Bool("all-repos"),
+		Units:                   units,
+	})
+	if err != nil {
+		return err
+	}
+
+	print.TeamDetails(team, nil, nil)
+	return nil
+}
+
+func runTeamsEdit(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if ctx.Args().Len() != 2 {
+		return fmt.Errorf("You have to specify the organization and team name")
+	}
+
+	team, err := task.FindTeam(client, ctx.Args().Get(0), ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	// the API expects all fields, so we start with the current settings
+	opts := gitea.EditTeamOption{
+		Name:                    team.Name,
+		Description:             &team.Description,
+		CanCreateOrgRepo:        &team.CanCreateOrgRepo,
+		IncludesAllRepositories: &team.IncludesAllRepositories,
+	}
+	if ctx.IsSet("name") {
+		opts.Name = ctx.String("name")
+	}
+	if ctx.IsSet("description") {
+		description := ctx.String("description")
+		opts.Description = &description
+	}
+	if ctx.IsSet("can-create-repos") {
+		canCreate := ctx.Bool("can-create-repos")
+		opts.CanCreateOrgRepo = &canCreate
+	}
+	if ctx.IsSet("all-repos") {
+		allRepos := ctx.Bool("all-repos")
+		opts.IncludesAllRepositories = &allRepos
+	}
+	if opts.Permission, err = getTeamPermission(ctx, team.Permission); err != nil {
+		return err
+	}
+	if opts.Units, err = getTeamUnits(ctx, team.Units); err != nil {
+		return err
+	}
+
+	if _, err = client.EditTeam(team.ID, opts); err != nil {
+		return err
+	}
+
+	if team, _, err = client.GetTeam(team.ID); err != nil {
+		return err
+	}
+	print.TeamDetails(team, nil, nil)
+	return nil
+}
+
+func runTeamsDelete(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if ctx.Args().Len() != 2 {
+		return fmt.Errorf("You have to specify the organization and team name")
+	}
+
+	if !ctx.Bool("confirm") {
+		fmt.Println("Are you sure? Please confirm with -y or --confirm.")
+		return nil
+	}
+
+	team, err := task.FindTeam(client, ctx.Args().Get(0), ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteTeam(team.ID)
+	return err
+}
+
+func getTeamPermission(ctx *context.TeaContext, defaultPermission gitea.AccessMode) (gitea.AccessMode, error) {
+	if !ctx.IsSet("permission") {
+		return defaultPermission, nil
+	}
+	permission := gitea.AccessMode(ctx.String("permission"))
+	switch permission {
+	case gitea.AccessModeRead, gitea.AccessModeWrite, gitea.AccessModeAdmin:
+		return permission, nil
+	}
+	return "", fmt.Errorf("unknown permission '%s'", permission)
+}
+
+func getTeamUnits(ctx *context.TeaContext, defaultUnits []gitea.RepoUnitType) ([]gitea.RepoUnitType, error) {
+	if !ctx.IsSet("units") {
+		return defaultUnits, nil
+	}
+	names, err := teamUnitsFlag.GetValues(ctx.Context)
+	if err != nil {
+		return nil, err
+	}
+	units := make([]gitea.RepoUnitType, 0, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); len(name) != 0 {
+			units = append(units, gitea.RepoUnitType("repo."+name))
+		}
+	}
+	return units, nil
+}
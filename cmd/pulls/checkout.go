@@ -18,31 +18,55 @@ import (
 
 // CmdPullsCheckout is a command to locally checkout the given PR
 var CmdPullsCheckout = cli.Command{
-	Name:        "checkout",
-	Aliases:     []string{"co"},
-	Usage:       "Locally check out the given PR",
-	Description: `Locally check out the given PR`,
-	Action:      runPullsCheckout,
-	ArgsUsage:   "<pull index>",
+	Name:    "checkout",
+	Aliases: []string{"co"},
+	Usage:   "Locally check out the given PR",
+	Description: `Locally check out the given PR.
+With --worktree, the PR is checked out into a new linked worktree at the given path,
+leaving the current worktree untouched. This requires a local git installation.`,
+	Action:    runPullsCheckout,
+	ArgsUsage: "<pull index> [<worktree path>]",
 	Flags: append([]cli.Flag{
 		&cli.BoolFlag{
 			Name:    "branch",
 			Aliases: []string{"b"},
 			Usage:   "Create a local branch if it doesn't exist yet",
 		},
+		&cli.BoolFlag{
+			Name:    "worktree",
+			Aliases: []string{"w"},
+			Usage:   "Check out into a new linked worktree, defaults to '../<repo>-pull-<index>'",
+		},
 	}, flags.AllDefaultFlags...),
 }
 
 func runPullsCheckout(cmd *cli.Context) error {
 	ctx := context.InitCommand(cmd)
 	ctx.Ensure(context.CtxRequirement{LocalRepo: true})
-	if ctx.Args().Len() != 1 {
+	if ctx.Args().Len() < 1 {
 		return fmt.Errorf("Must specify a PR index")
 	}
+	if ctx.Args().Len() > 1 && !ctx.Bool("worktree") {
+		return fmt.Errorf("A worktree path can only be given with --worktree")
+	}
+	if ctx.Args().Len() > 2 {
+		return fmt.Errorf("Too many arguments")
+	}
 	idx, err := utils.ArgToIndex(ctx.Args().First())
 	if err != nil {
 		return err
 	}
 
+	if ctx.Bool("worktree") {
+		return task.PullCheckoutWorktree(
+			ctx.Login,
+			ctx.Owner,
+			ctx.Repo,
+			ctx.Bool("branch"),
+			idx,
+			ctx.Args().Get(1),
+			interact.PromptPassword)
+	}
+
 	return task.PullCheckout(ctx.Login, ctx.Owner, ctx.Repo, ctx.Bool("branch"), idx, interact.PromptPassword)
 }
@@ -18,22 +18,45 @@ import (
 
 // CmdPullsClean removes the remote and local feature branches, if a PR is merged.
 var CmdPullsClean = cli.Command{
-	Name:        "clean",
-	Usage:       "Deletes local & remote feature-branches for a closed pull request",
-	Description: `Deletes local & remote feature-branches for a closed pull request`,
-	ArgsUsage:   "<pull index>",
-	Action:      runPullsClean,
+	Name:  "clean",
+	Usage: "Deletes local & remote feature-branches for a closed pull request",
+	Description: `Deletes local & remote feature-branches for a closed pull request.
+Linked worktrees which have the branch checked out are removed as well.
+With --all-merged, all local branches belonging to closed pull requests are cleaned up.`,
+	ArgsUsage: "<pull index>",
+	Action:    runPullsClean,
 	Flags: append([]cli.Flag{
 		&cli.BoolFlag{
 			Name:  "ignore-sha",
 			Usage: "Find the local branch by name instead of commit hash (less precise)",
 		},
+		&cli.BoolFlag{
+			Name:  "all-merged",
+			Usage: "Clean up the branches of all closed pull requests instead of a single one",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Only list the branches that would be deleted by --all-merged",
+		},
+		&cli.BoolFlag{
+			Name:    "confirm",
+			Aliases: []string{"y"},
+			Usage:   "Don't ask for confirmation before deleting branches with --all-merged",
+		},
 	}, flags.AllDefaultFlags...),
 }
 
 func runPullsClean(cmd *cli.Context) error {
 	ctx := context.InitCommand(cmd)
 	ctx.Ensure(context.CtxRequirement{LocalRepo: true})
+
+	if ctx.Bool("all-merged") {
+		return runPullsCleanAll(ctx)
+	}
+	if ctx.Bool("dry-run") {
+		return fmt.Errorf("--dry-run is only supported together with --all-merged")
+	}
+
 	if ctx.Args().Len() != 1 {
 		return fmt.Errorf("Must specify a PR index")
 	}
@@ -45,3 +68,42 @@ func runPullsClean(cmd *cli.Context) error {
 
 	return task.PullClean(ctx.Login, ctx.Owner, ctx.Repo, idx, ctx.Bool("ignore-sha"), interact.PromptPassword)
 }
+
+func runPullsCleanAll(ctx *context.TeaContext) error {
+	branches, err := task.FindClosedPullBranches(ctx.Login, ctx.Owner, ctx.Repo, ctx.Bool("ignore-sha"))
+	if err != nil {
+		return err
+	}
+	if len(branches) == 0 {
+		fmt.Println("No local branches of closed pull requests found")
+		return nil
+	}
+
+	fmt.Println("Local branches of closed pull requests:")
+	for _, b := range branches {
+		fmt.Printf("  %s\t#%d %s (%s)\n", b.Branch.Name, b.Pull.Index, b.Pull.Title, pullStateName(b.Pull.HasMerged))
+	}
+
+	if ctx.Bool("dry-run") {
+		return nil
+	}
+
+	if !ctx.Bool("confirm") {
+		confirmed, err := interact.PromptConfirm(fmt.Sprintf("Delete %d branches locally & remotely?", len(branches)), false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	return task.PullCleanBranches(ctx.Login, ctx.Owner, ctx.Repo, branches, interact.PromptPassword)
+}
+
+func pullStateName(merged bool) string {
+	if merged {
+		return "merged"
+	}
+	return "closed"
+}
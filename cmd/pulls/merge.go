@@ -10,6 +10,8 @@ import (
 	"code.gitea.io/sdk/gitea"
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/interact"
+	"code.gitea.io/tea/modules/task"
 	"code.gitea.io/tea/modules/utils"
 
 	"github.com/urfave/cli/v2"
@@ -17,11 +19,13 @@ import (
 
 // CmdPullsMerge merges a PR
 var CmdPullsMerge = cli.Command{
-	Name:        "merge",
-	Aliases:     []string{"m"},
-	Usage:       "Merge a pull request",
-	Description: "Merge a pull request",
-	ArgsUsage:   "<pull index>",
+	Name:    "merge",
+	Aliases: []string{"m"},
+	Usage:   "Merge a pull request",
+	Description: `Merge a pull request.
+With --local, the merge is performed locally via git and pushed to the base branch,
+instead of merging on the server. This requires a local git installation.`,
+	ArgsUsage: "<pull index>",
 	Flags: append([]cli.Flag{
 		&cli.StringFlag{
 			Name:    "style",
@@ -39,6 +43,14 @@ var CmdPullsMerge = cli.Command{
 			Aliases: []string{"m"},
 			Usage:   "Merge commit message",
 		},
+		&cli.BoolFlag{
+			Name:  "local",
+			Usage: "Merge locally via git & push the result, instead of merging on the server",
+		},
+		&cli.BoolFlag{
+			Name:  "sign",
+			Usage: "GPG-sign the resulting commits (requires --local)",
+		},
 	}, flags.AllDefaultFlags...),
 	Action: func(cmd *cli.Context) error {
 		ctx := context.InitCommand(cmd)
@@ -53,8 +65,26 @@ var CmdPullsMerge = cli.Command{
 			return err
 		}
 
+		style := gitea.MergeStyle(ctx.String("style"))
+		if ctx.Bool("sign") && !ctx.Bool("local") {
+			return fmt.Errorf("--sign requires --local")
+		}
+		if ctx.Bool("local") {
+			ctx.Ensure(context.CtxRequirement{LocalRepo: true})
+			return task.PullMergeLocal(
+				ctx.Login,
+				ctx.Owner,
+				ctx.Repo,
+				idx,
+				style,
+				ctx.String("title"),
+				ctx.String("message"),
+				ctx.Bool("sign"),
+				interact.PromptPassword)
+		}
+
 		success, _, err := ctx.Login.Client().MergePullRequest(ctx.Owner, ctx.Repo, idx, gitea.MergePullRequestOption{
-			Style:   gitea.MergeStyle(ctx.String("style")),
+			Style:   style,
 			Title:   ctx.String("title"),
 			Message: ctx.String("message"),
 		})
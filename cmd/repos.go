@@ -29,6 +29,19 @@ var CmdRepos = cli.Command{
 		&repos.CmdRepoCreate,
 		&repos.CmdRepoCreateFromTemplate,
 		&repos.CmdRepoFork,
+		&repos.CmdRepoMigrate,
+		&repos.CmdRepoMirrorSync,
+		&repos.CmdRepoLs,
+		&repos.CmdRepoCat,
+		&repos.CmdRepoEdit,
+		&repos.CmdRepoTopics,
+		&repos.CmdRepoCollaborators,
+		&repos.CmdRepoDeployKeys,
+		&repos.CmdRepoGitHooks,
+		&repos.CmdRepoArchive,
+		&repos.CmdRepoUnarchive,
+		&repos.CmdRepoTransfer,
+		&repos.CmdRepoDelete,
 	},
 	Flags: repos.CmdReposListFlags,
 }
@@ -0,0 +1,65 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repos
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdRepoArchive represents a sub command of repos to archive them
+var CmdRepoArchive = cli.Command{
+	Name:        "archive",
+	Usage:       "Archive repositories",
+	Description: "Archive the current repo, or all repos given as arguments, making them read-only",
+	ArgsUsage:   "[<owner>/<repo>...]",
+	Action: func(cmd *cli.Context) error {
+		return setRepoArchived(cmd, true)
+	},
+	Flags: flags.LoginRepoFlags,
+}
+
+// CmdRepoUnarchive represents a sub command of repos to unarchive them
+var CmdRepoUnarchive = cli.Command{
+	Name:        "unarchive",
+	Usage:       "Unarchive repositories",
+	Description: "Unarchive the current repo, or all repos given as arguments",
+	ArgsUsage:   "[<owner>/<repo>...]",
+	Action: func(cmd *cli.Context) error {
+		return setRepoArchived(cmd, false)
+	},
+	Flags: flags.LoginRepoFlags,
+}
+
+func setRepoArchived(cmd *cli.Context, archived bool) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	repos := ctx.Args().Slice()
+	if len(repos) == 0 {
+		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+		repos = []string{ctx.RepoSlug}
+	}
+
+	for _, slug := range repos {
+		owner, repo := utils.GetOwnerAndRepo(slug, ctx.Login.User)
+		r, _, err := client.EditRepo(owner, repo, gitea.EditRepoOption{Archived: &archived})
+		if err != nil {
+			return fmt.Errorf("could not edit %s/%s: %s", owner, repo, err)
+		}
+		if r.Archived {
+			fmt.Printf("Archived %s\n", r.FullName)
+		} else {
+			fmt.Printf("Unarchived %s\n", r.FullName)
+		}
+	}
+	return nil
+}
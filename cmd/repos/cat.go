@@ -0,0 +1,54 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repos
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdRepoCat represents a sub command of repos to print files
+var CmdRepoCat = cli.Command{
+	Name:        "cat",
+	Usage:       "Print a file of a repository",
+	Description: "Print the raw content of a file in the repository, without cloning it",
+	ArgsUsage:   "<path>",
+	Action:      runRepoCat,
+	Flags: append([]cli.Flag{
+		&refFlag,
+		&cli.BoolFlag{
+			Name:  "render",
+			Usage: "render the file as markdown",
+		},
+	}, flags.LoginRepoFlags...),
+}
+
+func runRepoCat(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a file path")
+	}
+
+	ref, err := getRef(ctx)
+	if err != nil {
+		return err
+	}
+
+	content, _, err := ctx.Login.Client().GetFile(ctx.Owner, ctx.Repo, ref, strings.TrimPrefix(ctx.Args().First(), "/"))
+	if err != nil {
+		return err
+	}
+
+	print.FileContent(content, ctx.Bool("render"))
+	return nil
+}
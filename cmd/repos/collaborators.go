@@ -0,0 +1,173 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repos
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+var collaboratorFieldsFlag = flags.FieldsFlag(print.UserFields, []string{
+	"login", "full_name", "email",
+})
+
+var collaboratorsListFlags = append([]cli.Flag{
+	collaboratorFieldsFlag,
+	&flags.PaginationPageFlag,
+	&flags.PaginationLimitFlag,
+}, flags.AllDefaultFlags...)
+
+// CmdRepoCollaborators represents a sub command of repos to manage collaborators
+var CmdRepoCollaborators = cli.Command{
+	Name:        "collaborators",
+	Aliases:     []string{"collaborator", "collab"},
+	Usage:       "Manage repository collaborators",
+	Description: "Manage the users with direct access to the repository",
+	Action:      runRepoCollaboratorsList,
+	Subcommands: []*cli.Command{
+		&CmdRepoCollaboratorsList,
+		&CmdRepoCollaboratorsAdd,
+		&CmdRepoCollaboratorsRemove,
+		&CmdRepoCollaboratorsCheck,
+	},
+	Flags: collaboratorsListFlags,
+}
+
+// CmdRepoCollaboratorsList represents a sub command of collaborators to list them
+var CmdRepoCollaboratorsList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List repository collaborators",
+	Description: "List repository collaborators",
+	Action:      runRepoCollaboratorsList,
+	Flags:       collaboratorsListFlags,
+}
+
+// CmdRepoCollaboratorsAdd represents a sub command of collaborators to add users
+var CmdRepoCollaboratorsAdd = cli.Command{
+	Name:        "add",
+	Aliases:     []string{"a"},
+	Usage:       "Add collaborators to the repository",
+	Description: "Add users as collaborators to the repository, or change the permission of existing collaborators",
+	ArgsUsage:   "<username> [<username>...]",
+	Action:      runRepoCollaboratorsAdd,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:    "permission",
+			Aliases: []string{"p"},
+			Usage:   "permission to grant (read, write, admin)",
+			Value:   string(gitea.AccessModeWrite),
+		},
+	}, flags.LoginRepoFlags...),
+}
+
+// CmdRepoCollaboratorsRemove represents a sub command of collaborators to remove users
+var CmdRepoCollaboratorsRemove = cli.Command{
+	Name:        "remove",
+	Aliases:     []string{"rm"},
+	Usage:       "Remove collaborators from the repository",
+	Description: "Remove collaborators from the repository",
+	ArgsUsage:   "<username> [<username>...]",
+	Action:      runRepoCollaboratorsRemove,
+	Flags:       flags.LoginRepoFlags,
+}
+
+// CmdRepoCollaboratorsCheck represents a sub command of collaborators to check a user
+var CmdRepoCollaboratorsCheck = cli.Command{
+	Name:        "check",
+	Usage:       "Check if a user is a collaborator of the repository",
+	Description: "Check if a user is a collaborator of the repository. Exits with an error if not.",
+	ArgsUsage:   "<username>",
+	Action:      runRepoCollaboratorsCheck,
+	Flags:       flags.LoginRepoFlags,
+}
+
+func runRepoCollaboratorsList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+
+	users, _, err := ctx.Login.Client().ListCollaborators(ctx.Owner, ctx.Repo, gitea.ListCollaboratorsOptions{
+		ListOptions: ctx.GetListOptions(),
+	})
+	if err != nil {
+		return err
+	}
+
+	fields, err := collaboratorFieldsFlag.GetValues(cmd)
+	if err != nil {
+		return err
+	}
+	print.UserList(users, ctx.Output, fields)
+	return nil
+}
+
+func runRepoCollaboratorsAdd(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+	client := ctx.Login.Client()
+
+	if !ctx.Args().Present() {
+		return fmt.Errorf("Must specify at least one username")
+	}
+
+	permission := gitea.AccessMode(ctx.String("permission"))
+	switch permission {
+	case gitea.AccessModeRead, gitea.AccessModeWrite, gitea.AccessModeAdmin:
+	default:
+		return fmt.Errorf("unknown permission '%s'", permission)
+	}
+
+	for _, user := range ctx.Args().Slice() {
+		if _, err := client.AddCollaborator(ctx.Owner, ctx.Repo, user, gitea.AddCollaboratorOption{Permission: &permission}); err != nil {
+			return fmt.Errorf("could not add %s: %s", user, err)
+		}
+		fmt.Printf("Added %s to %s with %s permission\n", user, ctx.RepoSlug, permission)
+	}
+	return nil
+}
+
+func runRepoCollaboratorsRemove(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+	client := ctx.Login.Client()
+
+	if !ctx.Args().Present() {
+		return fmt.Errorf("Must specify at least one username")
+	}
+
+	for _, user := range ctx.Args().Slice() {
+		if _, err := client.DeleteCollaborator(ctx.Owner, ctx.Repo, user); err != nil {
+			return fmt.Errorf("could not remove %s: %s", user, err)
+		}
+		fmt.Printf("Removed %s from %s\n", user, ctx.RepoSlug)
+	}
+	return nil
+}
+
+func runRepoCollaboratorsCheck(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a username")
+	}
+	user := ctx.Args().First()
+
+	isCollaborator, _, err := ctx.Login.Client().IsCollaborator(ctx.Owner, ctx.Repo, user)
+	if err != nil {
+		return err
+	}
+	if !isCollaborator {
+		return fmt.Errorf("%s is not a collaborator of %s", user, ctx.RepoSlug)
+	}
+	fmt.Printf("%s is a collaborator of %s\n", user, ctx.RepoSlug)
+	return nil
+}
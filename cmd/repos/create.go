@@ -93,12 +93,34 @@ var CmdRepoCreate = cli.Command{
 func runRepoCreate(cmd *cli.Context) error {
 	ctx := context.InitCommand(cmd)
 	client := ctx.Login.Client()
-	var (
-		repo       *gitea.Repository
-		err        error
-		trustmodel gitea.TrustModel
-	)
+	var repo *gitea.Repository
 
+	opts, err := GetCreateRepoOption(ctx)
+	if err != nil {
+		return err
+	}
+	if len(ctx.String("owner")) != 0 {
+		repo, _, err = client.CreateOrgRepo(ctx.String("owner"), *opts)
+	} else {
+		repo, _, err = client.CreateRepo(*opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	topics, _, err := client.ListRepoTopics(repo.Owner.UserName, repo.Name, gitea.ListRepoTopicsOptions{})
+	if err != nil {
+		return err
+	}
+	print.RepoDetails(repo, topics)
+
+	fmt.Printf("%s\n", repo.HTMLURL)
+	return nil
+}
+
+// GetCreateRepoOption builds the options to create a repo from the flags of CmdRepoCreate
+func GetCreateRepoOption(ctx *context.TeaContext) (*gitea.CreateRepoOption, error) {
+	var trustmodel gitea.TrustModel
 	if ctx.IsSet("trustmodel") {
 		switch ctx.String("trustmodel") {
 		case "committer":
@@ -108,11 +130,11 @@ func runRepoCreate(cmd *cli.Context) error {
 		case "collaborator+committer":
 			trustmodel = gitea.TrustModelCollaboratorCommitter
 		default:
-			return fmt.Errorf("unknown trustmodel type '%s'", ctx.String("trustmodel"))
+			return nil, fmt.Errorf("unknown trustmodel type '%s'", ctx.String("trustmodel"))
 		}
 	}
 
-	opts := gitea.CreateRepoOption{
+	return &gitea.CreateRepoOption{
 		Name:          ctx.String("name"),
 		Description:   ctx.String("description"),
 		Private:       ctx.Bool("private"),
@@ -124,22 +146,5 @@ func runRepoCreate(cmd *cli.Context) error {
 		DefaultBranch: ctx.String("branch"),
 		Template:      ctx.Bool("template"),
 		TrustModel:    trustmodel,
-	}
-	if len(ctx.String("owner")) != 0 {
-		repo, _, err = client.CreateOrgRepo(ctx.String("owner"), opts)
-	} else {
-		repo, _, err = client.CreateRepo(opts)
-	}
-	if err != nil {
-		return err
-	}
-
-	topics, _, err := client.ListRepoTopics(repo.Owner.UserName, repo.Name, gitea.ListRepoTopicsOptions{})
-	if err != nil {
-		return err
-	}
-	print.RepoDetails(repo, topics)
-
-	fmt.Printf("%s\n", repo.HTMLURL)
-	return nil
+	}, nil
 }
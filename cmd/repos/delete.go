@@ -0,0 +1,72 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repos
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/interact"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdRepoDelete represents a sub command of repos to delete one
+var CmdRepoDelete = cli.Command{
+	Name:    "delete",
+	Aliases: []string{"rm"},
+	Usage:   "Delete a repository",
+	Description: `Delete a repository, including all its issues, pull requests, releases and wiki.
+This can not be undone, so you will be asked to type the full name of the repo to confirm.
+When running non-interactively, pass the full name of the repo to --confirm instead.`,
+	ArgsUsage: "[<owner>/<repo>]",
+	Action:    runRepoDelete,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "confirm",
+			Usage: "confirm deletion by providing the full name <owner>/<repo> of the repo",
+		},
+	}, flags.LoginRepoFlags...),
+}
+
+func runRepoDelete(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	slug := ctx.Args().First()
+	if len(slug) == 0 {
+		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+		slug = ctx.RepoSlug
+	}
+	owner, repoName := utils.GetOwnerAndRepo(slug, ctx.Login.User)
+	repo, _, err := client.GetRepo(owner, repoName)
+	if err != nil {
+		return err
+	}
+
+	confirmation := ctx.String("confirm")
+	if !ctx.IsSet("confirm") {
+		if interact.IsStdinPiped() {
+			return fmt.Errorf("Please confirm deletion with --confirm %s", repo.FullName)
+		}
+		fmt.Printf("This will permanently delete %s with %d issues, %d stars and %d forks.\n",
+			repo.FullName, repo.OpenIssues, repo.Stars, repo.Forks)
+		if confirmation, err = interact.PromptInput(fmt.Sprintf("Type '%s' to confirm:", repo.FullName)); err != nil {
+			return err
+		}
+	}
+	if !strings.EqualFold(strings.TrimSpace(confirmation), repo.FullName) {
+		return fmt.Errorf("Confirmation '%s' does not match '%s', aborting", confirmation, repo.FullName)
+	}
+
+	if _, err = client.DeleteRepo(repo.Owner.UserName, repo.Name); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %s\n", repo.FullName)
+	return nil
+}
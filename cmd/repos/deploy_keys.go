@@ -0,0 +1,182 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repos
+
+import (
+	"fmt"
+	"strconv"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/crypto/ssh"
+)
+
+var deployKeysListFlags = append([]cli.Flag{
+	&flags.PaginationPageFlag,
+	&flags.PaginationLimitFlag,
+}, flags.AllDefaultFlags...)
+
+// CmdRepoDeployKeys represents a sub command of repos to manage deploy keys
+var CmdRepoDeployKeys = cli.Command{
+	Name:        "deploy-keys",
+	Aliases:     []string{"deploy-key", "dk"},
+	Usage:       "Manage repository deploy keys",
+	Description: "Manage the ssh keys with access to the repository",
+	Action:      runRepoDeployKeysList,
+	Subcommands: []*cli.Command{
+		&CmdRepoDeployKeysList,
+		&CmdRepoDeployKeysAdd,
+		&CmdRepoDeployKeysRemove,
+	},
+	Flags: deployKeysListFlags,
+}
+
+// CmdRepoDeployKeysList represents a sub command of deploy keys to list them
+var CmdRepoDeployKeysList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List deploy keys",
+	Description: "List deploy keys",
+	Action:      runRepoDeployKeysList,
+	Flags:       deployKeysListFlags,
+}
+
+// CmdRepoDeployKeysAdd represents a sub command of deploy keys to add one
+var CmdRepoDeployKeysAdd = cli.Command{
+	Name:    "add",
+	Aliases: []string{"a"},
+	Usage:   "Add a deploy key",
+	Description: `Add a deploy key, either by reading a public key from a file, or
+by generating a new ed25519 keypair, which is stored locally.`,
+	ArgsUsage: "<title>",
+	Action:    runRepoDeployKeysAdd,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:    "key-file",
+			Aliases: []string{"k"},
+			Usage:   "public key file to add",
+		},
+		&cli.StringFlag{
+			Name:    "generate",
+			Aliases: []string{"g"},
+			Usage:   "generate a new keypair, and store the private key at the given path",
+		},
+		&cli.BoolFlag{
+			Name:  "write",
+			Usage: "grant write access to the key",
+		},
+	}, flags.LoginRepoFlags...),
+}
+
+// CmdRepoDeployKeysRemove represents a sub command of deploy keys to remove them
+var CmdRepoDeployKeysRemove = cli.Command{
+	Name:        "remove",
+	Aliases:     []string{"rm"},
+	Usage:       "Remove deploy keys",
+	Description: "Remove deploy keys, by their ID or fingerprint",
+	ArgsUsage:   "<key id | fingerprint> [<key id | fingerprint>...]",
+	Action:      runRepoDeployKeysRemove,
+	Flags:       flags.LoginRepoFlags,
+}
+
+func runRepoDeployKeysList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+
+	keys, _, err := ctx.Login.Client().ListDeployKeys(ctx.Owner, ctx.Repo, gitea.ListDeployKeysOptions{
+		ListOptions: ctx.GetListOptions(),
+	})
+	if err != nil {
+		return err
+	}
+
+	print.DeployKeysList(keys, ctx.Output)
+	return nil
+}
+
+func runRepoDeployKeysAdd(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a title")
+	}
+	title := ctx.Args().First()
+
+	var (
+		key     ssh.PublicKey
+		content string
+		err     error
+	)
+	switch {
+	case ctx.IsSet("key-file") && ctx.IsSet("generate"):
+		return fmt.Errorf("--key-file and --generate are mutually exclusive")
+	case ctx.IsSet("key-file"):
+		key, content, err = task.ReadSSHPublicKey(ctx.String("key-file"))
+	case ctx.IsSet("generate"):
+		var path string
+		if path, err = utils.AbsPathWithExpansion(ctx.String("generate")); err != nil {
+			return err
+		}
+		key, content, err = task.GenerateSSHKey(path, title)
+		if err == nil {
+			fmt.Printf("Stored private key at %s\n", path)
+		}
+	default:
+		return fmt.Errorf("Must specify either --key-file or --generate")
+	}
+	if err != nil {
+		return err
+	}
+
+	dk, _, err := ctx.Login.Client().CreateDeployKey(ctx.Owner, ctx.Repo, gitea.CreateKeyOption{
+		Title:    title,
+		Key:      content,
+		ReadOnly: !ctx.Bool("write"),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Added deploy key %d to %s: %s\n", dk.ID, ctx.RepoSlug, ssh.FingerprintSHA256(key))
+	return nil
+}
+
+func runRepoDeployKeysRemove(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+	client := ctx.Login.Client()
+
+	if !ctx.Args().Present() {
+		return fmt.Errorf("Must specify at least one key id or fingerprint")
+	}
+
+	for _, arg := range ctx.Args().Slice() {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			// not an ID, so look up the key by its fingerprint
+			keys, _, err := client.ListDeployKeys(ctx.Owner, ctx.Repo, gitea.ListDeployKeysOptions{Fingerprint: arg})
+			if err != nil {
+				return err
+			}
+			if len(keys) == 0 {
+				return fmt.Errorf("no deploy key with fingerprint %s found", arg)
+			}
+			id = keys[0].ID
+		}
+
+		if _, err = client.DeleteDeployKey(ctx.Owner, ctx.Repo, id); err != nil {
+			return fmt.Errorf("could not remove deploy key %s: %s", arg, err)
+		}
+		fmt.Printf("Removed deploy key %s from %s\n", arg, ctx.RepoSlug)
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repos
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/interact"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+var repoEditFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "name",
+		Usage: "rename the repo",
+	},
+	&cli.StringFlag{
+		Name:    "description",
+		Aliases: []string{"desc"},
+		Usage:   "set the repo description",
+	},
+	&cli.StringFlag{
+		Name:  "website",
+		Usage: "set the repo website",
+	},
+	&cli.StringFlag{
+		Name:  "default-branch",
+		Usage: "set the default branch",
+	},
+	&cli.BoolFlag{
+		Name:  "private",
+		Usage: "make repo private",
+	},
+	&cli.BoolFlag{
+		Name:  "template",
+		Usage: "make repo a template repo",
+	},
+	&cli.BoolFlag{
+		Name:  "archived",
+		Usage: "archive the repo",
+	},
+	&cli.BoolFlag{
+		Name:  "issues",
+		Usage: "enable issues",
+	},
+	&cli.BoolFlag{
+		Name:  "time-tracking",
+		Usage: "enable time tracking on the internal issue tracker",
+	},
+	&cli.BoolFlag{
+		Name:  "time-tracking-contributors-only",
+		Usage: "let only contributors track time",
+	},
+	&cli.BoolFlag{
+		Name:  "issue-dependencies",
+		Usage: "enable issue dependencies",
+	},
+	&cli.StringFlag{
+		Name:  "external-tracker-url",
+		Usage: "use an external issue tracker at this URL",
+	},
+	&cli.StringFlag{
+		Name:  "external-tracker-format",
+		Usage: "URL format of the external issue tracker, using the placeholders {user}, {repo} and {index}",
+	},
+	&cli.StringFlag{
+		Name:  "external-tracker-style",
+		Usage: "issue number format of the external issue tracker (numeric, alphanumeric)",
+	},
+	&cli.BoolFlag{
+		Name:  "wiki",
+		Usage: "enable the wiki",
+	},
+	&cli.StringFlag{
+		Name:  "external-wiki-url",
+		Usage: "use an external wiki at this URL",
+	},
+	&cli.BoolFlag{
+		Name:  "pulls",
+		Usage: "enable pull requests",
+	},
+	&cli.BoolFlag{
+		Name:  "projects",
+		Usage: "enable projects",
+	},
+	&cli.BoolFlag{
+		Name:  "ignore-whitespace-conflicts",
+		Usage: "ignore whitespace for conflicts in pull requests",
+	},
+	&cli.BoolFlag{
+		Name:  "allow-merge",
+		Usage: "allow merging pull requests with a merge commit",
+	},
+	&cli.BoolFlag{
+		Name:  "allow-rebase",
+		Usage: "allow rebasing pull requests",
+	},
+	&cli.BoolFlag{
+		Name:  "allow-rebase-merge",
+		Usage: "allow rebasing pull requests with a merge commit",
+	},
+	&cli.BoolFlag{
+		Name:  "allow-squash",
+		Usage: "allow squash-merging pull requests",
+	},
+	&cli.BoolFlag{
+		Name:  "allow-manual-merge",
+		Usage: "allow marking pull requests as merged manually",
+	},
+	&cli.BoolFlag{
+		Name:  "autodetect-manual-merge",
+		Usage: "detect pull requests merged manually",
+	},
+	&cli.StringFlag{
+		Name:  "default-merge-style",
+		Usage: "set the default merge style (merge, rebase, rebase-merge, squash)",
+	},
+	&cli.StringFlag{
+		Name:  "mirror-interval",
+		Usage: "set the mirror interval, eg. 8h30m0s",
+	},
+}
+
+// CmdRepoEdit represents a sub command of repos to edit their settings
+var CmdRepoEdit = cli.Command{
+	Name:    "edit",
+	Aliases: []string{"e"},
+	Usage:   "Edit repository settings",
+	Description: `Edit the settings of the current repo, or of all repos given as arguments.
+Only settings given as flags are changed. To disable a setting, pass eg. --wiki=false.
+When no settings are given, all settings are edited interactively.`,
+	ArgsUsage: "[<owner>/<repo>...]",
+	Action:    runRepoEdit,
+	Flags:     append(repoEditFlags, flags.LoginRepoFlags...),
+}
+
+func runRepoEdit(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	repos := ctx.Args().Slice()
+	if len(repos) == 0 {
+		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+		repos = []string{ctx.RepoSlug}
+	}
+
+	if !isAnyFlagSet(ctx, repoEditFlags) {
+		if len(repos) != 1 {
+			return fmt.Errorf("interactive mode supports only a single repo")
+		}
+		owner, repo := utils.GetOwnerAndRepo(repos[0], ctx.Login.User)
+		return interact.EditRepo(ctx.Login, owner, repo)
+	}
+
+	if ctx.IsSet("name") && len(repos) != 1 {
+		return fmt.Errorf("--name can only be used with a single repo")
+	}
+
+	client := ctx.Login.Client()
+	for _, slug := range repos {
+		owner, repo := utils.GetOwnerAndRepo(slug, ctx.Login.User)
+		r, _, err := client.GetRepo(owner, repo)
+		if err != nil {
+			return err
+		}
+		opts, err := getEditRepoOption(ctx, r)
+		if err != nil {
+			return err
+		}
+		if err = task.EditRepo(ctx.Login, owner, repo, *opts); err != nil {
+			return fmt.Errorf("could not edit %s/%s: %s", owner, repo, err)
+		}
+	}
+	return nil
+}
+
+// getEditRepoOption builds the options from the flags, using the current repo
+// settings for the parts of nested options not given as flag
+func getEditRepoOption(ctx *context.TeaContext, current *gitea.Repository) (*gitea.EditRepoOption, error) {
+	opts := &gitea.EditRepoOption{
+		Name:                      optionalString(ctx, "name"),
+		Description:               optionalString(ctx, "description"),
+		Website:                   optionalString(ctx, "website"),
+		DefaultBranch:             optionalString(ctx, "default-branch"),
+		Private:                   optionalBool(ctx, "private"),
+		Template:                  optionalBool(ctx, "template"),
+		Archived:                  optionalBool(ctx, "archived"),
+		HasIssues:                 optionalBool(ctx, "issues"),
+		HasWiki:                   optionalBool(ctx, "wiki"),
+		HasPullRequests:           optionalBool(ctx, "pulls"),
+		HasProjects:               optionalBool(ctx, "projects"),
+		IgnoreWhitespaceConflicts: optionalBool(ctx, "ignore-whitespace-conflicts"),
+		AllowMerge:                optionalBool(ctx, "allow-merge"),
+		AllowRebase:               optionalBool(ctx, "allow-rebase"),
+		AllowRebaseMerge:          optionalBool(ctx, "allow-rebase-merge"),
+		AllowSquash:               optionalBool(ctx, "allow-squash"),
+		AllowManualMerge:          optionalBool(ctx, "allow-manual-merge"),
+		AutodetectManualMerge:     optionalBool(ctx, "autodetect-manual-merge"),
+		MirrorInterval:            optionalString(ctx, "mirror-interval"),
+	}
+
+	if ctx.IsSet("default-merge-style") {
+		style := gitea.MergeStyle(ctx.String("default-merge-style"))
+		switch style {
+		case gitea.MergeStyleMerge, gitea.MergeStyleRebase, gitea.MergeStyleRebaseMerge, gitea.MergeStyleSquash:
+			opts.DefaultMergeStyle = &style
+		default:
+			return nil, fmt.Errorf("unknown merge style '%s'", style)
+		}
+	}
+
+	if ctx.IsSet("time-tracking") || ctx.IsSet("time-tracking-contributors-only") || ctx.IsSet("issue-dependencies") {
+		tracker := gitea.InternalTracker{}
+		if current.InternalTracker != nil {
+			tracker = *current.InternalTracker
+		}
+		if ctx.IsSet("time-tracking") {
+			tracker.EnableTimeTracker = ctx.Bool("time-tracking")
+		}
+		if ctx.IsSet("time-tracking-contributors-only") {
+			tracker.AllowOnlyContributorsToTrackTime = ctx.Bool("time-tracking-contributors-only")
+		}
+		if ctx.IsSet("issue-dependencies") {
+			tracker.EnableIssueDependencies = ctx.Bool("issue-dependencies")
+		}
+		opts.InternalTracker = &tracker
+	}
+
+	if ctx.IsSet("external-tracker-url") || ctx.IsSet("external-tracker-format") || ctx.IsSet("external-tracker-style") {
+		tracker := gitea.ExternalTracker{}
+		if current.ExternalTracker != nil {
+			tracker = *current.ExternalTracker
+		}
+		if ctx.IsSet("external-tracker-url") {
+			tracker.ExternalTrackerURL = ctx.String("external-tracker-url")
+		}
+		if ctx.IsSet("external-tracker-format") {
+			tracker.ExternalTrackerFormat = ctx.String("external-tracker-format")
+		}
+		if ctx.IsSet("external-tracker-style") {
+			tracker.ExternalTrackerStyle = ctx.String("external-tracker-style")
+		}
+		opts.ExternalTracker = &tracker
+	}
+
+	if ctx.IsSet("external-wiki-url") {
+		opts.ExternalWiki = &gitea.ExternalWiki{ExternalWikiURL: ctx.String("external-wiki-url")}
+	}
+
+	return opts, nil
+}
+
+func isAnyFlagSet(ctx *context.TeaContext, flags []cli.Flag) bool {
+	for _, f := range flags {
+		if ctx.IsSet(f.Names()[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+func optionalString(ctx *context.TeaContext, name string) *string {
+	if !ctx.IsSet(name) {
+		return nil
+	}
+	value := ctx.String(name)
+	return &value
+}
+
+func optionalBool(ctx *context.TeaContext, name string) *bool {
+	if !ctx.IsSet(name) {
+		return nil
+	}
+	value := ctx.Bool(name)
+	return &value
+}
@@ -0,0 +1,263 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repos
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdRepoGitHooks represents a sub command of repos to manage server side git hooks
+var CmdRepoGitHooks = cli.Command{
+	Name:        "git-hooks",
+	Aliases:     []string{"git-hook"},
+	Usage:       "Manage server side git hooks",
+	Description: "Manage the server side git hooks (pre-receive, update, post-receive) of a repository. Requires admin permissions.",
+	Action:      runRepoGitHooksList,
+	Subcommands: []*cli.Command{
+		&CmdRepoGitHooksList,
+		&CmdRepoGitHooksShow,
+		&CmdRepoGitHooksEdit,
+		&CmdRepoGitHooksDelete,
+		&CmdRepoGitHooksDiff,
+	},
+	Flags: flags.AllDefaultFlags,
+}
+
+// CmdRepoGitHooksList represents a sub command of git hooks to list them
+var CmdRepoGitHooksList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List git hooks",
+	Description: "List git hooks",
+	Action:      runRepoGitHooksList,
+	Flags:       flags.AllDefaultFlags,
+}
+
+// CmdRepoGitHooksShow represents a sub command of git hooks to print one
+var CmdRepoGitHooksShow = cli.Command{
+	Name:        "show",
+	Usage:       "Print the content of a git hook",
+	Description: "Print the content of a git hook",
+	ArgsUsage:   "<hook name>",
+	Action:      runRepoGitHooksShow,
+	Flags:       flags.LoginRepoFlags,
+}
+
+// CmdRepoGitHooksEdit represents a sub command of git hooks to edit one
+var CmdRepoGitHooksEdit = cli.Command{
+	Name:    "edit",
+	Aliases: []string{"e"},
+	Usage:   "Edit a git hook",
+	Description: `Edit a git hook in your $EDITOR, or replace its content with a file.
+When reading from a file, the hook can be set in multiple repos at once.`,
+	ArgsUsage: "<hook name> [<owner>/<repo>...]",
+	Action:    runRepoGitHooksEdit,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "read the hook content from this file, use - for stdin",
+		},
+	}, flags.LoginRepoFlags...),
+}
+
+// CmdRepoGitHooksDelete represents a sub command of git hooks to delete one
+var CmdRepoGitHooksDelete = cli.Command{
+	Name:        "delete",
+	Aliases:     []string{"rm"},
+	Usage:       "Delete the content of a git hook",
+	Description: "Delete the content of a git hook, deactivating it",
+	ArgsUsage:   "<hook name>",
+	Action:      runRepoGitHooksDelete,
+	Flags: append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:    "confirm",
+			Aliases: []string{"y"},
+			Usage:   "Confirm deletion (required)",
+		},
+	}, flags.LoginRepoFlags...),
+}
+
+// CmdRepoGitHooksDiff represents a sub command of git hooks to compare them across repos
+var CmdRepoGitHooksDiff = cli.Command{
+	Name:        "diff",
+	Usage:       "Compare a git hook across repos",
+	Description: "Compare a git hook of the current repo to the same hook in other repos",
+	ArgsUsage:   "<hook name> <owner>/<repo> [<owner>/<repo>...]",
+	Action:      runRepoGitHooksDiff,
+	Flags:       flags.LoginRepoFlags,
+}
+
+func runRepoGitHooksList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+
+	hooks, _, err := ctx.Login.Client().ListRepoGitHooks(ctx.Owner, ctx.Repo, gitea.ListRepoGitHooksOptions{})
+	if err != nil {
+		return err
+	}
+
+	print.GitHooksList(hooks, ctx.Output)
+	return nil
+}
+
+func runRepoGitHooksShow(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a hook name")
+	}
+
+	hook, _, err := ctx.Login.Client().GetRepoGitHook(ctx.Owner, ctx.Repo, ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	print.GitHookContent(hook)
+	return nil
+}
+
+func runRepoGitHooksEdit(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if !ctx.Args().Present() {
+		return fmt.Errorf("Must specify a hook name")
+	}
+	name := ctx.Args().First()
+	repos := ctx.Args().Tail()
+	if len(repos) == 0 {
+		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+		repos = []string{ctx.RepoSlug}
+	}
+
+	var content string
+	if ctx.IsSet("file") {
+		var raw []byte
+		var err error
+		if ctx.String("file") == "-" {
+			raw, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			raw, err = ioutil.ReadFile(ctx.String("file"))
+		}
+		if err != nil {
+			return err
+		}
+		content = string(raw)
+	} else {
+		if len(repos) != 1 {
+			return fmt.Errorf("editing multiple repos at once requires --file")
+		}
+		owner, repo := utils.GetOwnerAndRepo(repos[0], ctx.Login.User)
+		hook, _, err := client.GetRepoGitHook(owner, repo, name)
+		if err != nil {
+			return err
+		}
+		edited, err := editGitHook(hook)
+		if err != nil {
+			return err
+		}
+		if edited == hook.Content {
+			fmt.Println("No changes made")
+			return nil
+		}
+		content = edited
+	}
+
+	for _, slug := range repos {
+		owner, repo := utils.GetOwnerAndRepo(slug, ctx.Login.User)
+		if _, err := client.EditRepoGitHook(owner, repo, name, gitea.EditGitHookOption{Content: content}); err != nil {
+			return fmt.Errorf("could not edit %s hook of %s/%s: %s", name, owner, repo, err)
+		}
+		fmt.Printf("Updated %s hook of %s/%s\n", name, owner, repo)
+	}
+	return nil
+}
+
+// editGitHook opens the content of the hook in the users editor, and returns the result
+func editGitHook(hook *gitea.GitHook) (string, error) {
+	f, err := ioutil.TempFile("", "tea-"+hook.Name+"-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.WriteString(hook.Content); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err = f.Close(); err != nil {
+		return "", err
+	}
+
+	if err = task.OpenFileInEditor(f.Name()); err != nil {
+		return "", err
+	}
+	raw, err := ioutil.ReadFile(f.Name())
+	return string(raw), err
+}
+
+func runRepoGitHooksDelete(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a hook name")
+	}
+
+	if !ctx.Bool("confirm") {
+		fmt.Println("Are you sure? Please confirm with -y or --confirm.")
+		return nil
+	}
+
+	name := ctx.Args().First()
+	if _, err := ctx.Login.Client().DeleteRepoGitHook(ctx.Owner, ctx.Repo, name); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted git hook %s of %s\n", name, ctx.RepoSlug)
+	return nil
+}
+
+func runRepoGitHooksDiff(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+	client := ctx.Login.Client()
+
+	if ctx.Args().Len() < 2 {
+		return fmt.Errorf("Must specify a hook name and at least one repo to compare with")
+	}
+	name := ctx.Args().First()
+
+	base, _, err := client.GetRepoGitHook(ctx.Owner, ctx.Repo, name)
+	if err != nil {
+		return err
+	}
+
+	for _, slug := range ctx.Args().Tail() {
+		owner, repo := utils.GetOwnerAndRepo(slug, ctx.Login.User)
+		other, _, err := client.GetRepoGitHook(owner, repo, name)
+		if err != nil {
+			return fmt.Errorf("could not get %s hook of %s/%s: %s", name, owner, repo, err)
+		}
+		otherSlug := fmt.Sprintf("%s/%s", owner, repo)
+		if other.Content == base.Content {
+			fmt.Printf("%s: identical to %s\n", otherSlug, ctx.RepoSlug)
+			continue
+		}
+		print.TextDiff(ctx.RepoSlug+"/"+name, otherSlug+"/"+name, base.Content, other.Content)
+	}
+	return nil
+}
@@ -40,7 +40,6 @@ var CmdReposListFlags = append([]cli.Flag{
 // CmdReposList represents a sub command of repos to list them
 var CmdReposList = cli.Command{
 	Name:        "list",
-	Aliases:     []string{"ls"},
 	Usage:       "List repositories you have access to",
 	Description: "List repositories you have access to",
 	Action:      RunReposList,
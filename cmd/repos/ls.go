@@ -0,0 +1,103 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repos
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+var refFlag = cli.StringFlag{
+	Name:  "ref",
+	Usage: "branch, tag or commit to read from. Defaults to the default branch",
+}
+
+// CmdRepoLs represents a sub command of repos to list files
+var CmdRepoLs = cli.Command{
+	Name:        "ls",
+	Usage:       "List files of a repository",
+	Description: "List the files in a directory of the repository, without cloning it",
+	ArgsUsage:   "[<path>]",
+	Action:      runRepoLs,
+	Flags: append([]cli.Flag{
+		&refFlag,
+		&cli.BoolFlag{
+			Name:  "recursive",
+			Usage: "list the contents of all subdirectories",
+		},
+	}, flags.AllDefaultFlags...),
+}
+
+func runRepoLs(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+	client := ctx.Login.Client()
+	path := strings.Trim(ctx.Args().First(), "/")
+
+	if ctx.Bool("recursive") {
+		return listRepoTree(ctx, path)
+	}
+
+	contents, _, err := client.ListContents(ctx.Owner, ctx.Repo, ctx.String("ref"), path)
+	if err != nil {
+		// path might point to a file instead of a directory
+		file, _, fileErr := client.GetContents(ctx.Owner, ctx.Repo, ctx.String("ref"), path)
+		if fileErr != nil {
+			return err
+		}
+		contents = []*gitea.ContentsResponse{file}
+	}
+
+	print.ContentsList(contents, ctx.Output)
+	return nil
+}
+
+func listRepoTree(ctx *context.TeaContext, path string) error {
+	client := ctx.Login.Client()
+	ref, err := getRef(ctx)
+	if err != nil {
+		return err
+	}
+
+	tree, _, err := client.GetTrees(ctx.Owner, ctx.Repo, ref, true)
+	if err != nil {
+		return err
+	}
+
+	entries := tree.Entries
+	if len(path) != 0 {
+		entries = make([]gitea.GitEntry, 0, len(tree.Entries))
+		for _, e := range tree.Entries {
+			if strings.HasPrefix(e.Path, path+"/") {
+				entries = append(entries, e)
+			}
+		}
+	}
+
+	print.TreeList(entries, ctx.Output)
+	if tree.Truncated {
+		fmt.Println("NOTE: the tree is too large, not all entries are listed")
+	}
+	return nil
+}
+
+// getRef returns the value of the ref flag, or the default branch of the repo
+func getRef(ctx *context.TeaContext) (string, error) {
+	if ctx.IsSet("ref") {
+		return ctx.String("ref"), nil
+	}
+	repo, _, err := ctx.Login.Client().GetRepo(ctx.Owner, ctx.Repo)
+	if err != nil {
+		return "", err
+	}
+	return repo.DefaultBranch, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repos
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+var migrateItems = []string{"wiki", "milestones", "labels", "issues", "pulls", "releases", "lfs"}
+
+var migrateItemsFlag = flags.NewCsvFlag("items", "items to migrate in addition to the git data", nil, migrateItems, nil)
+
+// CmdRepoMigrate represents a sub command of repos to migrate one from another service
+var CmdRepoMigrate = cli.Command{
+	Name:    "migrate",
+	Aliases: []string{"m"},
+	Usage:   "Migrate a repository from another git host",
+	Description: `Migrate a repository from GitHub, GitLab, Gitea, Gogs or a plain git server.
+
+To migrate many repos at once, pass a file via --file, listing one source repo per line:
+	<clone url> [<repo name>]
+All other flags apply to every repo in the file.`,
+	ArgsUsage: "<clone url>",
+	Action:    runRepoMigrate,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "name",
+			Usage: "name of the new repo, defaults to the name of the source repo",
+		},
+		&cli.StringFlag{
+			Name:    "owner",
+			Aliases: []string{"O"},
+			Usage:   "user or organization owning the new repo",
+		},
+		&cli.StringFlag{
+			Name:  "service",
+			Usage: "type of the source service (git, github, gitlab, gitea, gogs)",
+			Value: string(gitea.GitServicePlain),
+		},
+		&cli.StringFlag{
+			Name:  "auth-token",
+			Usage: "access token for the source service",
+		},
+		&cli.StringFlag{
+			Name:  "auth-user",
+			Usage: "username for the source service",
+		},
+		&cli.StringFlag{
+			Name:  "auth-password",
+			Usage: "password for the source service",
+		},
+		&cli.BoolFlag{
+			Name:  "mirror",
+			Usage: "keep the new repo as pull mirror of the source",
+		},
+		&cli.StringFlag{
+			Name:  "mirror-interval",
+			Usage: "update interval of the mirror, eg. 8h30m0s",
+		},
+		&cli.BoolFlag{
+			Name:  "private",
+			Usage: "make the new repo private",
+		},
+		&cli.StringFlag{
+			Name:    "description",
+			Aliases: []string{"desc"},
+			Usage:   "description of the new repo",
+		},
+		migrateItemsFlag,
+		&cli.StringFlag{
+			Name:  "lfs-endpoint",
+			Usage: "custom LFS endpoint of the source (needs --items lfs)",
+		},
+		&cli.StringFlag{
+			Name:    "file",
+			Aliases: []string{"f"},
+			Usage:   "migrate all repos listed in this file",
+		},
+	}, flags.LoginOutputFlags...),
+}
+
+func runRepoMigrate(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	var service gitea.GitServiceType
+	switch s := gitea.GitServiceType(ctx.String("service")); s {
+	case gitea.GitServicePlain, gitea.GitServiceGithub, gitea.GitServiceGitlab, gitea.GitServiceGitea, gitea.GitServiceGogs:
+		service = s
+	default:
+		return fmt.Errorf("unknown service type '%s'", s)
+	}
+
+	items, err := migrateItemsFlag.GetValues(cmd)
+	if err != nil {
+		return err
+	}
+
+	opts := gitea.MigrateRepoOption{
+		RepoOwner:      ctx.String("owner"),
+		Service:        service,
+		AuthToken:      ctx.String("auth-token"),
+		AuthUsername:   ctx.String("auth-user"),
+		AuthPassword:   ctx.String("auth-password"),
+		Mirror:         ctx.Bool("mirror"),
+		MirrorInterval: ctx.String("mirror-interval"),
+		Private:        ctx.Bool("private"),
+		Description:    ctx.String("description"),
+		Wiki:           utils.Contains(items, "wiki"),
+		Milestones:     utils.Contains(items, "milestones"),
+		Labels:         utils.Contains(items, "labels"),
+		Issues:         utils.Contains(items, "issues"),
+		PullRequests:   utils.Contains(items, "pulls"),
+		Releases:       utils.Contains(items, "releases"),
+		LFS:            utils.Contains(items, "lfs"),
+		LFSEndpoint:    ctx.String("lfs-endpoint"),
+	}
+
+	if ctx.IsSet("file") {
+		if ctx.Args().Present() || ctx.IsSet("name") {
+			return fmt.Errorf("--file can't be combined with a clone url or --name")
+		}
+		sources, err := task.ReadMigrationSources(ctx.String("file"))
+		if err != nil {
+			return err
+		}
+		return task.MigrateRepos(client, opts, sources)
+	}
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a clone url")
+	}
+	opts.CloneAddr = ctx.Args().First()
+	opts.RepoName = ctx.String("name")
+	if len(opts.RepoName) == 0 {
+		opts.RepoName = task.RepoNameFromURL(opts.CloneAddr)
+	}
+
+	repo, _, err := client.MigrateRepo(opts)
+	if err != nil {
+		return err
+	}
+	print.RepoDetails(repo, nil)
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repos
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdRepoMirrorSync represents a sub command of repos to update pull mirrors
+var CmdRepoMirrorSync = cli.Command{
+	Name:        "mirror-sync",
+	Aliases:     []string{"sync"},
+	Usage:       "Update pull mirrors from their source",
+	Description: "Queue an update of the current repo, or of all repos given as arguments, from their mirror source",
+	ArgsUsage:   "[<owner>/<repo>...]",
+	Action:      runRepoMirrorSync,
+	Flags:       flags.LoginRepoFlags,
+}
+
+func runRepoMirrorSync(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	repos := ctx.Args().Slice()
+	if len(repos) == 0 {
+		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+		repos = []string{ctx.RepoSlug}
+	}
+
+	for _, slug := range repos {
+		owner, repo := utils.GetOwnerAndRepo(slug, ctx.Login.User)
+		if _, err := client.MirrorSync(owner, repo); err != nil {
+			return fmt.Errorf("could not sync %s/%s: %s", owner, repo, err)
+		}
+		fmt.Printf("Queued mirror sync for %s/%s\n", owner, repo)
+	}
+	return nil
+}
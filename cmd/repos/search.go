@@ -24,42 +24,74 @@ var CmdReposSearch = cli.Command{
 	Description: "Find any repo on an Gitea instance",
 	ArgsUsage:   "[<search term>]",
 	Action:      runReposSearch,
-	Flags: append([]cli.Flag{
-		&cli.BoolFlag{
-			// TODO: it might be nice to search for topics as an ADDITIONAL filter.
-			// for that, we'd probably need to make multiple queries and UNION the results.
-			Name:     "topic",
-			Aliases:  []string{"t"},
-			Required: false,
-			Usage:    "Search for term in repo topics instead of name",
-		},
-		&typeFilterFlag,
-		&cli.StringFlag{
-			Name:     "owner",
-			Aliases:  []string{"O"},
-			Required: false,
-			Usage:    "Filter by owner",
-		},
-		&cli.StringFlag{
-			Name:     "private",
-			Required: false,
-			Usage:    "Filter private repos (true|false)",
-		},
-		&cli.StringFlag{
-			Name:     "archived",
-			Required: false,
-			Usage:    "Filter archived repos (true|false)",
-		},
+	Flags: append(append([]cli.Flag{
 		repoFieldsFlag,
 		&flags.PaginationPageFlag,
 		&flags.PaginationLimitFlag,
-	}, flags.LoginOutputFlags...),
+	}, repoSearchFilterFlags...), flags.LoginOutputFlags...),
+}
+
+// repoSearchFilterFlags are the flags to filter the results of a repo search
+var repoSearchFilterFlags = []cli.Flag{
+	&cli.BoolFlag{
+		// TODO: it might be nice to search for topics as an ADDITIONAL filter.
+		// for that, we'd probably need to make multiple queries and UNION the results.
+		Name:     "topic",
+		Aliases:  []string{"t"},
+		Required: false,
+		Usage:    "Search for term in repo topics instead of name",
+	},
+	&typeFilterFlag,
+	&cli.StringFlag{
+		Name:     "owner",
+		Aliases:  []string{"O"},
+		Required: false,
+		Usage:    "Filter by owner",
+	},
+	&cli.StringFlag{
+		Name:     "private",
+		Required: false,
+		Usage:    "Filter private repos (true|false)",
+	},
+	&cli.StringFlag{
+		Name:     "archived",
+		Required: false,
+		Usage:    "Filter archived repos (true|false)",
+	},
 }
 
 func runReposSearch(cmd *cli.Context) error {
 	ctx := context.InitCommand(cmd)
 	client := ctx.Login.Client()
 
+	var keyword string
+	if ctx.Args().Present() {
+		keyword = strings.Join(ctx.Args().Slice(), " ")
+	}
+
+	opts, err := getSearchRepoOptions(ctx, keyword)
+	if err != nil {
+		return err
+	}
+	opts.ListOptions = ctx.GetListOptions()
+
+	rps, _, err := client.SearchRepos(*opts)
+	if err != nil {
+		return err
+	}
+
+	fields, err := repoFieldsFlag.GetValues(cmd)
+	if err != nil {
+		return err
+	}
+	print.ReposList(rps, ctx.Output, fields)
+	return nil
+}
+
+// getSearchRepoOptions builds the repo search options from the repoSearchFilterFlags
+func getSearchRepoOptions(ctx *context.TeaContext, keyword string) (*gitea.SearchRepoOptions, error) {
+	client := ctx.Login.Client()
+
 	var ownerID int64
 	if ctx.IsSet("owner") {
 		// test if owner is a organisation
@@ -67,13 +99,13 @@ func runReposSearch(cmd *cli.Context) error {
 		if err != nil {
 			// HACK: the client does not return a response on 404, so we can't check res.StatusCode
 			if err.Error() != "404 Not Found" {
-				return fmt.Errorf("Could not find owner: %s", err)
+				return nil, fmt.Errorf("Could not find owner: %s", err)
 			}
 
 			// if owner is no org, its a user
 			user, _, err := client.GetUserInfo(ctx.String("owner"))
 			if err != nil {
-				return err
+				return nil, err
 			}
 			ownerID = user.ID
 		} else {
@@ -93,23 +125,17 @@ func runReposSearch(cmd *cli.Context) error {
 		isPrivate = &private
 	}
 
-	mode, err := getTypeFilter(cmd)
+	mode, err := getTypeFilter(ctx.Context)
 	if err != nil {
-		return err
-	}
-
-	var keyword string
-	if ctx.Args().Present() {
-		keyword = strings.Join(ctx.Args().Slice(), " ")
+		return nil, err
 	}
 
 	user, _, err := client.GetMyUserInfo()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	rps, _, err := client.SearchRepos(gitea.SearchRepoOptions{
-		ListOptions:          ctx.GetListOptions(),
+	return &gitea.SearchRepoOptions{
 		OwnerID:              ownerID,
 		IsPrivate:            isPrivate,
 		IsArchived:           isArchived,
@@ -118,15 +144,5 @@ func runReposSearch(cmd *cli.Context) error {
 		KeywordInDescription: true,
 		KeywordIsTopic:       ctx.Bool("topic"),
 		PrioritizedByOwnerID: user.ID,
-	})
-	if err != nil {
-		return err
-	}
-
-	fields, err := repoFieldsFlag.GetValues(cmd)
-	if err != nil {
-		return err
-	}
-	print.ReposList(rps, ctx.Output, fields)
-	return nil
+	}, nil
 }
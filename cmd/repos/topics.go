@@ -0,0 +1,207 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repos
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+var repoTopicsFlags = append(append([]cli.Flag{
+	&cli.StringFlag{
+		Name:    "search",
+		Aliases: []string{"s"},
+		Usage:   "Apply to all repos matching this search term instead of the current repo. Use '' to match all repos",
+	},
+}, repoSearchFilterFlags...), flags.LoginRepoFlags...)
+
+// repoTopicsEditFlags are the flags of sub commands changing topics
+var repoTopicsEditFlags = append([]cli.Flag{
+	&cli.BoolFlag{
+		Name:    "confirm",
+		Aliases: []string{"y"},
+		Usage:   "Confirm changing the topics of all repos matching --search",
+	},
+}, repoTopicsFlags...)
+
+// CmdRepoTopics represents a sub command of repos to manage their topics
+var CmdRepoTopics = cli.Command{
+	Name:    "topics",
+	Aliases: []string{"topic"},
+	Usage:   "Manage repository topics",
+	Description: `Manage the topics of the current repo.
+All sub commands accept the flags of 'tea repos search', to apply them to all
+repos matching the search term given via --search instead. Changes to the
+matching repos are only applied with --confirm, otherwise they are listed.`,
+	Action: runRepoTopicsList,
+	Subcommands: []*cli.Command{
+		&CmdRepoTopicsList,
+		&CmdRepoTopicsAdd,
+		&CmdRepoTopicsRemove,
+		&CmdRepoTopicsSet,
+	},
+	Flags: append([]cli.Flag{&flags.OutputFlag}, repoTopicsFlags...),
+}
+
+// CmdRepoTopicsList represents a sub command of repo topics to list them
+var CmdRepoTopicsList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List repository topics",
+	Description: "List the topics of the current repo or of all matching repos",
+	Action:      runRepoTopicsList,
+	Flags:       append([]cli.Flag{&flags.OutputFlag}, repoTopicsFlags...),
+}
+
+// CmdRepoTopicsAdd represents a sub command of repo topics to add topics
+var CmdRepoTopicsAdd = cli.Command{
+	Name:        "add",
+	Aliases:     []string{"a"},
+	Usage:       "Add topics to repositories",
+	Description: "Add topics to the current repo or to all matching repos",
+	ArgsUsage:   "<topic> [<topic>...]",
+	Action: func(cmd *cli.Context) error {
+		return editRepoTopics(cmd, false, func(client *gitea.Client, owner, repo string, topics []string) error {
+			for _, topic := range topics {
+				if _, err := client.AddRepoTopic(owner, repo, topic); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+	Flags: repoTopicsEditFlags,
+}
+
+// CmdRepoTopicsRemove represents a sub command of repo topics to remove topics
+var CmdRepoTopicsRemove = cli.Command{
+	Name:        "remove",
+	Aliases:     []string{"rm"},
+	Usage:       "Remove topics from repositories",
+	Description: "Remove topics from the current repo or from all matching repos",
+	ArgsUsage:   "<topic> [<topic>...]",
+	Action: func(cmd *cli.Context) error {
+		return editRepoTopics(cmd, false, func(client *gitea.Client, owner, repo string, topics []string) error {
+			for _, topic := range topics {
+				if _, err := client.DeleteRepoTopic(owner, repo, topic); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+	Flags: repoTopicsEditFlags,
+}
+
+// CmdRepoTopicsSet represents a sub command of repo topics to replace all topics
+var CmdRepoTopicsSet = cli.Command{
+	Name:        "set",
+	Usage:       "Replace the topics of repositories",
+	Description: "Replace all topics of the current repo or of all matching repos. Call without topics to remove all topics.",
+	ArgsUsage:   "[<topic>...]",
+	Action: func(cmd *cli.Context) error {
+		return editRepoTopics(cmd, true, func(client *gitea.Client, owner, repo string, topics []string) error {
+			_, err := client.SetRepoTopics(owner, repo, topics)
+			return err
+		})
+	},
+	Flags: repoTopicsEditFlags,
+}
+
+// topicsTarget is a repo whose topics are managed
+type topicsTarget struct {
+	owner, repo string
+}
+
+func (t topicsTarget) String() string {
+	return t.owner + "/" + t.repo
+}
+
+// getTopicsTargets returns the repos matching the --search flag, or the current repo
+func getTopicsTargets(ctx *context.TeaContext) ([]topicsTarget, error) {
+	if !ctx.IsSet("search") {
+		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+		return []topicsTarget{{ctx.Owner, ctx.Repo}}, nil
+	}
+
+	opts, err := getSearchRepoOptions(ctx, ctx.String("search"))
+	if err != nil {
+		return nil, err
+	}
+	repos, err := task.SearchAllRepos(ctx.Login.Client(), *opts)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]topicsTarget, len(repos))
+	for i, r := range repos {
+		targets[i] = topicsTarget{r.Owner.UserName, r.Name}
+	}
+	return targets, nil
+}
+
+func runRepoTopicsList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	targets, err := getTopicsTargets(ctx)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(targets))
+	topics := make(map[string][]string, len(targets))
+	for i, t := range targets {
+		names[i] = t.String()
+		if topics[names[i]], _, err = client.ListRepoTopics(t.owner, t.repo, gitea.ListRepoTopicsOptions{}); err != nil {
+			return err
+		}
+	}
+
+	print.RepoTopicsList(names, topics, ctx.Output)
+	return nil
+}
+
+func editRepoTopics(cmd *cli.Context, allowEmpty bool, edit func(client *gitea.Client, owner, repo string, topics []string) error) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	topics := ctx.Args().Slice()
+	if len(topics) == 0 && !allowEmpty {
+		return fmt.Errorf("Must specify at least one topic")
+	}
+
+	targets, err := getTopicsTargets(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ctx.IsSet("search") && !ctx.Bool("confirm") {
+		fmt.Printf("This changes the topics of %d repos:\n", len(targets))
+		for _, t := range targets {
+			fmt.Printf("  %s\n", t)
+		}
+		fmt.Println("Are you sure? Please confirm with -y or --confirm.")
+		return nil
+	}
+
+	for _, t := range targets {
+		if err = edit(client, t.owner, t.repo, topics); err != nil {
+			return fmt.Errorf("could not edit topics of %s: %s", t, err)
+		}
+		result, _, err := client.ListRepoTopics(t.owner, t.repo, gitea.ListRepoTopicsOptions{})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: %v\n", t, result)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repos
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdRepoTransfer represents a sub command of repos to transfer one to a new owner
+var CmdRepoTransfer = cli.Command{
+	Name:        "transfer",
+	Usage:       "Transfer a repository to a new owner",
+	Description: "Transfer the current repo or the given repo to another user or organization",
+	ArgsUsage:   "[<owner>/<repo>]",
+	Action:      runRepoTransfer,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:     "to",
+			Usage:    "name of the new owner",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "teams",
+			Usage: "comma separated list of teams of the new owner organization to add to the repo",
+		},
+	}, flags.LoginRepoFlags...),
+}
+
+func runRepoTransfer(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	slug := ctx.Args().First()
+	if len(slug) == 0 {
+		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+		slug = ctx.RepoSlug
+	}
+	owner, repo := utils.GetOwnerAndRepo(slug, ctx.Login.User)
+
+	opts := gitea.TransferRepoOption{NewOwner: ctx.String("to")}
+	if ctx.IsSet("teams") {
+		var names []string
+		for _, name := range strings.Split(ctx.String("teams"), ",") {
+			if name = strings.TrimSpace(name); len(name) != 0 {
+				names = append(names, name)
+			}
+		}
+		teams, err := task.FindTeams(client, opts.NewOwner, names)
+		if err != nil {
+			return err
+		}
+		teamIDs := make([]int64, len(teams))
+		for i, t := range teams {
+			teamIDs[i] = t.ID
+		}
+		opts.TeamIDs = &teamIDs
+	}
+
+	r, _, err := client.TransferRepo(owner, repo, opts)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(r.Owner.UserName, opts.NewOwner) {
+		fmt.Printf("Transferred %s/%s to %s\n", owner, repo, r.FullName)
+	} else {
+		// the server may require the new owner to accept the transfer first
+		fmt.Printf("Transfer of %s/%s to %s is pending, waiting for acceptance by the new owner\n", owner, repo, opts.NewOwner)
+	}
+	return nil
+}
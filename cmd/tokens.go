@@ -0,0 +1,28 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"code.gitea.io/tea/cmd/tokens"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdTokens represents the command to manage access tokens
+var CmdTokens = cli.Command{
+	Name:     "tokens",
+	Aliases:  []string{"token"},
+	Category: catSetup,
+	Usage:    "Manage your access tokens",
+	Description: `Manage the access tokens of your account. As the Gitea API requires
+authentication with username & password for this, you are asked for your password.`,
+	Action: tokens.RunTokensList,
+	Subcommands: []*cli.Command{
+		&tokens.CmdTokensList,
+		&tokens.CmdTokensCreate,
+		&tokens.CmdTokensDelete,
+	},
+	Flags: tokens.CmdTokensList.Flags,
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package tokens
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdTokensCreate represents a sub command of tokens to create one
+var CmdTokensCreate = cli.Command{
+	Name:    "create",
+	Aliases: []string{"c"},
+	Usage:   "Create an access token",
+	Description: `Create an access token. The token is only shown once, so make sure to
+store it. Use --output to print it in a machine readable format.`,
+	ArgsUsage: "<name>",
+	Action:    runTokensCreate,
+	Flags:     append(flags.BasicAuthFlags, flags.LoginOutputFlags...),
+}
+
+func runTokensCreate(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a name for the token")
+	}
+
+	client, err := basicAuthClient(ctx)
+	if err != nil {
+		return err
+	}
+	token, _, err := client.CreateAccessToken(gitea.CreateAccessTokenOption{Name: ctx.Args().First()})
+	if err != nil {
+		return err
+	}
+
+	if len(ctx.Output) == 0 {
+		fmt.Printf("Created access token '%s'. Make sure to copy it now, it won't be shown again:\n%s\n", token.Name, token.Token)
+		return nil
+	}
+	print.AccessTokensList([]*gitea.AccessToken{token}, ctx.Output)
+	return nil
+}
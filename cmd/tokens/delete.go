@@ -0,0 +1,64 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package tokens
+
+import (
+	"fmt"
+	"strconv"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdTokensDelete represents a sub command of tokens to delete them
+var CmdTokensDelete = cli.Command{
+	Name:        "delete",
+	Aliases:     []string{"rm"},
+	Usage:       "Delete access tokens",
+	Description: "Delete access tokens by their ID or name. Clients using them lose access",
+	ArgsUsage:   "<token id | name> [<token id | name>...]",
+	Action:      runTokensDelete,
+	Flags: append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:    "confirm",
+			Aliases: []string{"y"},
+			Usage:   "confirm deletion (required)",
+		},
+	}, append(flags.BasicAuthFlags, &flags.LoginFlag)...),
+}
+
+func runTokensDelete(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	if !ctx.Args().Present() {
+		return fmt.Errorf("Must specify at least one token id or name")
+	}
+	if !ctx.Bool("confirm") {
+		fmt.Println("Are you sure? Please confirm with -y or --confirm.")
+		return nil
+	}
+
+	client, err := basicAuthClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, arg := range ctx.Args().Slice() {
+		var token interface{} = arg
+		if id, err := strconv.ParseInt(arg, 10, 64); err == nil {
+			token = id
+		}
+		if _, err = client.DeleteAccessToken(token); err != nil {
+			return fmt.Errorf("could not delete token %s: %s", arg, err)
+		}
+		fmt.Printf("Deleted access token %s\n", arg)
+		if id, ok := token.(int64); ok && id == ctx.Login.TokenID {
+			fmt.Printf("Warning: this was the token of login '%s', which won't work anymore\n", ctx.Login.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package tokens
+
+import (
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/interact"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdTokensList represents a sub command of tokens to list them
+var CmdTokensList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List access tokens",
+	Description: "List the access tokens of your account",
+	Action:      RunTokensList,
+	Flags: append([]cli.Flag{
+		&flags.PaginationPageFlag,
+		&flags.PaginationLimitFlag,
+	}, append(flags.BasicAuthFlags, flags.LoginOutputFlags...)...),
+}
+
+// RunTokensList lists the access tokens of the user
+func RunTokensList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	client, err := basicAuthClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	tokens, _, err := client.ListAccessTokens(gitea.ListAccessTokensOptions{
+		ListOptions: ctx.GetListOptions(),
+	})
+	if err != nil {
+		return err
+	}
+
+	print.AccessTokensList(tokens, ctx.Output)
+	return nil
+}
+
+// basicAuthClient returns a client authenticating with the credentials given
+// by flags, asking for them if required
+func basicAuthClient(ctx *context.TeaContext) (*gitea.Client, error) {
+	user := ctx.String("user")
+	if len(user) == 0 {
+		user = ctx.Login.User
+	}
+	return interact.BasicAuthClient(ctx.Login, user, ctx.String("password"))
+}
@@ -7,29 +7,44 @@ package cmd
 import (
 	"code.gitea.io/tea/cmd/users"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
 
 	"github.com/urfave/cli/v2"
 )
 
-// CmdOrgs represents handle organization
+// CmdUsers represents handle users
 var CmdUsers = cli.Command{
 	Name:        "users",
-	Aliases:     []string{"users"},
+	Aliases:     []string{"user"},
 	Category:    catEntities,
-	Usage:       "List, create, delete users",
+	Usage:       "Show users, and follow them",
 	Description: "Show user details",
 	ArgsUsage:   "[<user>]",
 	Action:      runUsers,
 	Subcommands: []*cli.Command{
 		&users.CmdUserList,
+		&users.CmdUserFollow,
+		&users.CmdUserUnfollow,
+		&users.CmdUserFollowers,
+		&users.CmdUserFollowing,
 	},
-	//Flags: organizations.CmdOrganizationList.Flags,
+	Flags: users.CmdUserList.Flags,
 }
 
 func runUsers(cmd *cli.Context) error {
 	ctx := context.InitCommand(cmd)
 	if ctx.Args().Len() == 1 {
-		//	return runOrganizationDetail(ctx)
+		return runUserDetail(ctx)
 	}
 	return users.RunUserList(cmd)
 }
+
+func runUserDetail(ctx *context.TeaContext) error {
+	user, _, err := ctx.Login.Client().GetUserInfo(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	print.UserDetails(user)
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package users
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+var followFieldsFlag = flags.FieldsFlag(print.UserFields, []string{
+	"login", "full_name",
+})
+
+var followListFlags = append([]cli.Flag{
+	followFieldsFlag,
+	&flags.PaginationPageFlag,
+	&flags.PaginationLimitFlag,
+}, flags.LoginOutputFlags...)
+
+// CmdUserFollow represents a sub command of users to follow users
+var CmdUserFollow = cli.Command{
+	Name:        "follow",
+	Usage:       "Follow users",
+	Description: "Follow users",
+	ArgsUsage:   "<username> [<username>...]",
+	Action: func(cmd *cli.Context) error {
+		return editFollows(cmd, "Following %s\n", func(client *gitea.Client, user string) error {
+			_, err := client.Follow(user)
+			return err
+		})
+	},
+	Flags: []cli.Flag{&flags.LoginFlag},
+}
+
+// CmdUserUnfollow represents a sub command of users to unfollow users
+var CmdUserUnfollow = cli.Command{
+	Name:        "unfollow",
+	Usage:       "Unfollow users",
+	Description: "Unfollow users",
+	ArgsUsage:   "<username> [<username>...]",
+	Action: func(cmd *cli.Context) error {
+		return editFollows(cmd, "Unfollowed %s\n", func(client *gitea.Client, user string) error {
+			_, err := client.Unfollow(user)
+			return err
+		})
+	},
+	Flags: []cli.Flag{&flags.LoginFlag},
+}
+
+// CmdUserFollowers represents a sub command of users to list followers
+var CmdUserFollowers = cli.Command{
+	Name:        "followers",
+	Usage:       "List followers of a user",
+	Description: "List followers of a user, defaulting to yourself",
+	ArgsUsage:   "[<username>]",
+	Action: func(cmd *cli.Context) error {
+		return listFollows(cmd, func(client *gitea.Client, user string, opts gitea.ListOptions) ([]*gitea.User, error) {
+			users, _, err := client.ListFollowers(user, gitea.ListFollowersOptions{ListOptions: opts})
+			return users, err
+		})
+	},
+	Flags: followListFlags,
+}
+
+// CmdUserFollowing represents a sub command of users to list followed users
+var CmdUserFollowing = cli.Command{
+	Name:        "following",
+	Usage:       "List users followed by a user",
+	Description: "List users followed by a user, defaulting to yourself",
+	ArgsUsage:   "[<username>]",
+	Action: func(cmd *cli.Context) error {
+		return listFollows(cmd, func(client *gitea.Client, user string, opts gitea.ListOptions) ([]*gitea.User, error) {
+			users, _, err := client.ListFollowing(user, gitea.ListFollowingOptions{ListOptions: opts})
+			return users, err
+		})
+	},
+	Flags: followListFlags,
+}
+
+func editFollows(cmd *cli.Context, message string, edit func(client *gitea.Client, user string) error) error {
+	ctx := context.InitCommand(cmd)
+	client := ctx.Login.Client()
+
+	if !ctx.Args().Present() {
+		return fmt.Errorf("Must specify at least one username")
+	}
+
+	for _, user := range ctx.Args().Slice() {
+		if err := edit(client, user); err != nil {
+			return fmt.Errorf("%s: %s", user, err)
+		}
+		fmt.Printf(message, user)
+	}
+	return nil
+}
+
+func listFollows(cmd *cli.Context, list func(client *gitea.Client, user string, opts gitea.ListOptions) ([]*gitea.User, error)) error {
+	ctx := context.InitCommand(cmd)
+
+	user := ctx.Login.User
+	if ctx.Args().Present() {
+		user = ctx.Args().First()
+	}
+
+	users, err := list(ctx.Login.Client(), user, ctx.GetListOptions())
+	if err != nil {
+		return err
+	}
+
+	fields, err := followFieldsFlag.GetValues(cmd)
+	if err != nil {
+		return err
+	}
+	print.UserList(users, ctx.Output, fields)
+	return nil
+}
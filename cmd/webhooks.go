@@ -0,0 +1,59 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"code.gitea.io/tea/cmd/webhooks"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdWebhooks represents the command to manage webhooks
+var CmdWebhooks = cli.Command{
+	Name:        "webhooks",
+	Aliases:     []string{"webhook", "hooks", "hook"},
+	Category:    catEntities,
+	Usage:       "Manage webhooks",
+	Description: "Lists webhooks of a repo or organization when called without argument. If a webhook ID is provided, will show it in detail.",
+	ArgsUsage:   "[<webhook id>]",
+	Action:      runWebhooks,
+	Subcommands: []*cli.Command{
+		&webhooks.CmdWebhooksList,
+		&webhooks.CmdWebhooksCreate,
+		&webhooks.CmdWebhooksEdit,
+		&webhooks.CmdWebhooksDelete,
+		&webhooks.CmdWebhooksExport,
+		&webhooks.CmdWebhooksImport,
+		&webhooks.CmdWebhooksListen,
+	},
+	Flags: webhooks.CmdWebhooksList.Flags,
+}
+
+func runWebhooks(cmd *cli.Context) error {
+	if cmd.Args().Len() == 1 {
+		return runWebhookDetail(cmd, cmd.Args().First())
+	}
+	return webhooks.RunWebhooksList(cmd)
+}
+
+func runWebhookDetail(cmd *cli.Context, arg string) error {
+	ctx := context.InitCommand(cmd)
+	id, err := utils.ArgToIndex(arg)
+	if err != nil {
+		return err
+	}
+
+	scope := webhooks.GetScope(ctx)
+	hook, err := scope.Get(id)
+	if err != nil {
+		return err
+	}
+
+	print.WebhookDetails(hook)
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhooks
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdWebhooksCreate represents a sub command of webhooks to create one
+var CmdWebhooksCreate = cli.Command{
+	Name:        "create",
+	Aliases:     []string{"c"},
+	Usage:       "Create a webhook",
+	Description: "Create a webhook for a repo or organization",
+	ArgsUsage:   "<target url>",
+	Action:      runWebhooksCreate,
+	Flags: append(append([]cli.Flag{
+		&cli.StringFlag{
+			Name:    "type",
+			Aliases: []string{"t"},
+			Usage:   "type of the webhook (gitea, gogs, slack, discord, dingtalk, telegram, msteams, feishu, matrix)",
+			Value:   string(gitea.HookTypeGitea),
+		},
+	}, hookFlags...), ScopeFlags...),
+}
+
+func runWebhooksCreate(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	scope := GetScope(ctx)
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a target url")
+	}
+
+	config, err := getHookConfig(ctx)
+	if err != nil {
+		return err
+	}
+	config["url"] = ctx.Args().First()
+	if _, ok := config["content_type"]; !ok {
+		config["content_type"] = "json"
+	}
+
+	events := getEvents(ctx)
+	if events == nil {
+		events = []string{"push"}
+	}
+
+	hook, err := scope.Create(gitea.CreateHookOption{
+		Type:         gitea.HookType(ctx.String("type")),
+		Config:       config,
+		Events:       events,
+		BranchFilter: ctx.String("branch-filter"),
+		Active:       ctx.Bool("active"),
+	})
+	if err != nil {
+		return err
+	}
+
+	print.WebhookDetails(hook)
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhooks
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdWebhooksDelete represents a sub command of webhooks to delete them
+var CmdWebhooksDelete = cli.Command{
+	Name:        "delete",
+	Aliases:     []string{"rm"},
+	Usage:       "Delete webhooks",
+	Description: "Delete webhooks of a repo or organization",
+	ArgsUsage:   "<webhook id> [<webhook id>...]",
+	Action:      runWebhooksDelete,
+	Flags: append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:    "confirm",
+			Aliases: []string{"y"},
+			Usage:   "Confirm deletion (required)",
+		},
+	}, ScopeFlags...),
+}
+
+func runWebhooksDelete(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	scope := GetScope(ctx)
+
+	if !ctx.Args().Present() {
+		return fmt.Errorf("Must specify at least one webhook id")
+	}
+
+	if !ctx.Bool("confirm") {
+		fmt.Println("Are you sure? Please confirm with -y or --confirm.")
+		return nil
+	}
+
+	for _, arg := range ctx.Args().Slice() {
+		id, err := utils.ArgToIndex(arg)
+		if err != nil {
+			return err
+		}
+		if err = scope.Delete(id); err != nil {
+			return fmt.Errorf("could not delete webhook %d: %s", id, err)
+		}
+		fmt.Printf("Deleted webhook %d of %s\n", id, scope)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhooks
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdWebhooksEdit represents a sub command of webhooks to edit one
+var CmdWebhooksEdit = cli.Command{
+	Name:        "edit",
+	Aliases:     []string{"e"},
+	Usage:       "Edit a webhook",
+	Description: "Edit a webhook of a repo or organization. Only the settings given as flags are changed.",
+	ArgsUsage:   "<webhook id>",
+	Action:      runWebhooksEdit,
+	Flags: append(append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "url",
+			Usage: "target url of the webhook",
+		},
+	}, hookFlags...), ScopeFlags...),
+}
+
+func runWebhooksEdit(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	scope := GetScope(ctx)
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a webhook id")
+	}
+	id, err := utils.ArgToIndex(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	config, err := getHookConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if ctx.IsSet("url") {
+		config["url"] = ctx.String("url")
+	}
+
+	opts := gitea.EditHookOption{
+		Config: config,
+		Events: getEvents(ctx),
+	}
+	// the API resets the branch filter if it is not sent, so keep the current one
+	if ctx.IsSet("branch-filter") {
+		opts.BranchFilter = ctx.String("branch-filter")
+	} else {
+		filter, known, err := scope.BranchFilter(id)
+		if err != nil {
+			return err
+		}
+		if !known {
+			return fmt.Errorf("The server doesn't return the branch filter of webhook %d, specify it with --branch-filter to keep it ('*' for all branches)", id)
+		}
+		opts.BranchFilter = filter
+	}
+	if ctx.IsSet("active") {
+		active := ctx.Bool("active")
+		opts.Active = &active
+	}
+	if err = scope.Edit(id, opts); err != nil {
+		return err
+	}
+
+	hook, err := scope.Get(id)
+	if err != nil {
+		return err
+	}
+	print.WebhookDetails(hook)
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhooks
+
+import (
+	"fmt"
+
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/task"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdWebhooksExport represents a sub command of webhooks to save them to a file
+var CmdWebhooksExport = cli.Command{
+	Name:  "export",
+	Usage: "Save the webhooks as a YAML file",
+	Description: `Save the webhooks of a repo or organization as a YAML file, to be used with 'tea webhooks import'.
+Secrets are not exposed by the API, so they need to be added to the file manually.
+The same applies to branch filters on older servers.`,
+	ArgsUsage: "<file>",
+	Action:    runWebhooksExport,
+	Flags:     ScopeFlags,
+}
+
+// CmdWebhooksImport represents a sub command of webhooks to create them from a file
+var CmdWebhooksImport = cli.Command{
+	Name:  "import",
+	Usage: "Create or update webhooks from a YAML file",
+	Description: `Create or update the webhooks of a repo or organization from a YAML file, containing a list of webhooks:
+
+	- type: gitea
+	  url: https://ci.example.com/hook
+	  content_type: json
+	  secret: ${CI_WEBHOOK_SECRET}
+	  events: [push, pull_request]
+	  branch_filter: main
+	  active: true
+
+Existing webhooks with the same type and url are updated, keeping their branch
+filter if none is set. Webhooks are active, unless active is set to false.
+Environment variables in secrets are expanded, so they don't need to be versioned.`,
+	ArgsUsage: "<file>",
+	Action:    runWebhooksImport,
+	Flags:     ScopeFlags,
+}
+
+func runWebhooksExport(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	scope := GetScope(ctx)
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a file name")
+	}
+
+	hooks, err := scope.ListAll()
+	if err != nil {
+		return err
+	}
+	configs := make([]task.WebhookConfig, len(hooks))
+	for i, h := range hooks {
+		branchFilter, known, err := scope.BranchFilter(h.ID)
+		if err != nil {
+			return err
+		}
+		configs[i] = task.WebhookToConfig(h, branchFilter, known)
+	}
+	return task.WriteWebhookConfigs(configs, ctx.Args().First())
+}
+
+func runWebhooksImport(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	scope := GetScope(ctx)
+
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("Must specify a file name")
+	}
+
+	hooks, err := task.ReadWebhookConfigs(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	return task.ImportWebhooks(scope, hooks)
+}
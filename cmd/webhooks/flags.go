@@ -0,0 +1,92 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhooks
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/task"
+
+	"github.com/urfave/cli/v2"
+)
+
+var orgFlag = cli.StringFlag{
+	Name:  "org",
+	Usage: "Manage the webhooks of this organization instead of a repo",
+}
+
+// ScopeFlags are the flags to select the repo or organization owning the webhooks
+var ScopeFlags = append([]cli.Flag{&orgFlag}, flags.AllDefaultFlags...)
+
+// hookFlags configure a webhook on create & edit
+var hookFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "events",
+		Aliases: []string{"e"},
+		Usage:   "comma separated list of events to trigger the webhook, eg. push,pull_request,issues",
+	},
+	&cli.StringFlag{
+		Name:  "content-type",
+		Usage: "content type of the payload (json, form)",
+	},
+	&cli.StringFlag{
+		Name:  "secret",
+		Usage: "secret to sign the payload with",
+	},
+	&cli.StringFlag{
+		Name:  "branch-filter",
+		Usage: "only trigger for branches matching this glob pattern",
+	},
+	&cli.BoolFlag{
+		Name:  "active",
+		Usage: "trigger the webhook on events",
+		Value: true,
+	},
+	&cli.StringSliceFlag{
+		Name:  "config",
+		Usage: "additional type specific settings as key=value, eg. channel=#ci for slack. Can be repeated",
+	},
+}
+
+// GetScope returns the organization given via --org, or the current repo
+func GetScope(ctx *context.TeaContext) task.WebhookScope {
+	scope := task.WebhookScope{Login: ctx.Login, Client: ctx.Login.Client()}
+	if ctx.IsSet("org") {
+		scope.Org = ctx.String("org")
+	} else {
+		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+		scope.Owner, scope.Repo = ctx.Owner, ctx.Repo
+	}
+	return scope
+}
+
+// getHookConfig returns the webhook config keys set via flags
+func getHookConfig(ctx *context.TeaContext) (map[string]string, error) {
+	config := make(map[string]string)
+	for _, kv := range ctx.StringSlice("config") {
+		pair := strings.SplitN(kv, "=", 2)
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("invalid config '%s', expected key=value", kv)
+		}
+		config[pair[0]] = pair[1]
+	}
+	if ctx.IsSet("content-type") {
+		config["content_type"] = ctx.String("content-type")
+	}
+	if ctx.IsSet("secret") {
+		config["secret"] = ctx.String("secret")
+	}
+	return config, nil
+}
+
+func getEvents(ctx *context.TeaContext) []string {
+	if !ctx.IsSet("events") {
+		return nil
+	}
+	return strings.Split(ctx.String("events"), ",")
+}
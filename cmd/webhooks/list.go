@@ -0,0 +1,41 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhooks
+
+import (
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v2"
+)
+
+// CmdWebhooksList represents a sub command of webhooks to list them
+var CmdWebhooksList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List webhooks",
+	Description: "List the webhooks of a repo or organization",
+	Action:      RunWebhooksList,
+	Flags: append([]cli.Flag{
+		&flags.PaginationPageFlag,
+		&flags.PaginationLimitFlag,
+	}, ScopeFlags...),
+}
+
+// RunWebhooksList lists the webhooks of a repo or organization
+func RunWebhooksList(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+	scope := GetScope(ctx)
+
+	hooks, err := scope.List(gitea.ListHooksOptions{ListOptions: ctx.GetListOptions()})
+	if err != nil {
+		return err
+	}
+
+	print.WebhooksList(hooks, ctx.Output)
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhooks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/task"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CmdWebhooksListen represents a sub command of webhooks to receive webhook events locally
+var CmdWebhooksListen = cli.Command{
+	Name:  "listen",
+	Usage: "Receive and print webhook events locally",
+	Description: `Start a local HTTP server, which prints all webhook events it receives.
+Each payload can be forwarded to another URL, or passed to a command on stdin.
+The command gets the event type in $TEA_WEBHOOK_EVENT.
+
+With --register, a webhook pointing to this server is added to the repo or
+organization while the server runs, and removed again on exit.`,
+	Action: runWebhooksListen,
+	Flags: append([]cli.Flag{
+		&cli.IntFlag{
+			Name:    "port",
+			Aliases: []string{"p"},
+			Usage:   "port to listen on",
+			Value:   8080,
+		},
+		&cli.StringFlag{
+			Name:  "host",
+			Usage: "address to listen on. Use 0.0.0.0 to accept events from other hosts",
+			Value: "localhost",
+		},
+		&cli.StringFlag{
+			Name:  "secret",
+			Usage: "reject events which are not signed with this secret",
+		},
+		&cli.StringFlag{
+			Name:  "forward",
+			Usage: "forward all events to this URL",
+		},
+		&cli.StringFlag{
+			Name:  "exec",
+			Usage: "run this command for each event, with the payload on stdin",
+		},
+		&cli.BoolFlag{
+			Name:  "payload",
+			Usage: "print the full payload of each event",
+		},
+		&cli.BoolFlag{
+			Name:  "register",
+			Usage: "add a webhook for this server to the repo or organization until exit",
+		},
+		&cli.StringFlag{
+			Name:  "url",
+			Usage: "URL under which the Gitea server can reach this server, for --register. Defaults to http://<host>:<port>/",
+		},
+		&cli.StringFlag{
+			Name:    "events",
+			Aliases: []string{"e"},
+			Usage:   "comma separated list of events for --register",
+			Value:   "create,delete,push,issues,issue_comment,pull_request,release",
+		},
+	}, ScopeFlags...),
+}
+
+func runWebhooksListen(cmd *cli.Context) error {
+	ctx := context.InitCommand(cmd)
+
+	addr := net.JoinHostPort(ctx.String("host"), strconv.Itoa(ctx.Int("port")))
+	listener := &task.WebhookListener{
+		Secret:      ctx.String("secret"),
+		ForwardURL:  ctx.String("forward"),
+		Command:     ctx.String("exec"),
+		ShowPayload: ctx.Bool("payload"),
+	}
+
+	if !ctx.Bool("register") {
+		return task.ListenForWebhooks(listener, addr, nil, "", nil)
+	}
+
+	scope := GetScope(ctx)
+	if len(listener.Secret) == 0 {
+		secret := make([]byte, 16)
+		if _, err := rand.Read(secret); err != nil {
+			return err
+		}
+		listener.Secret = hex.EncodeToString(secret)
+	}
+	publicURL := ctx.String("url")
+	if len(publicURL) == 0 {
+		publicURL = fmt.Sprintf("http://%s/", addr)
+	}
+	// unlike other commands, the default value of --events applies here
+	events := strings.Split(ctx.String("events"), ",")
+	return task.ListenForWebhooks(listener, addr, &scope, publicURL, events)
+}
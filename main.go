@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"code.gitea.io/tea/cmd"
+	"code.gitea.io/tea/cmd/flags"
 
 	"github.com/urfave/cli/v2"
 )
@@ -31,26 +32,37 @@ func main() {
 	app.Description = appDescription
 	app.CustomAppHelpTemplate = helpTemplate
 	app.Version = Version + formatBuiltWith(Tags)
+	app.Flags = []cli.Flag{
+		&flags.SudoFlag,
+		&flags.OTPFlag,
+	}
 	app.Commands = []*cli.Command{
 		&cmd.CmdLogin,
 		&cmd.CmdLogout,
 		&cmd.CmdAutocomplete,
 		&cmd.CmdWhoami,
+		&cmd.CmdMe,
+		&cmd.CmdTokens,
+		&cmd.CmdOauthApps,
 
 		&cmd.CmdIssues,
 		&cmd.CmdPulls,
+		&cmd.CmdBranches,
 		&cmd.CmdLabels,
 		&cmd.CmdMilestones,
 		&cmd.CmdReleases,
 		&cmd.CmdTrackedTimes,
 		&cmd.CmdOrgs,
 		&cmd.CmdRepos,
+		&cmd.CmdWebhooks,
 		&cmd.CmdUsers,
 		&cmd.CmdAddComment,
 
 		&cmd.CmdOpen,
 		&cmd.CmdNotifications,
 		&cmd.CmdRepoClone,
+
+		&cmd.CmdAdmin,
 	}
 	app.EnableBashCompletion = true
 	err := app.Run(os.Args)
@@ -109,6 +121,7 @@ var helpTemplate = bold(`
    tea issue 189                       # view contents of issue 189
    tea open 189                        # open web ui for issue 189
    tea open milestones                 # open web ui for milestones
+   tea --sudo alice issues             # list issues as seen by user alice
 
    # send gitea desktop notifications every 5 minutes (bash + libnotify)
    while :; do tea notifications --mine -o simple | xargs -i notify-send {}; sleep 300; done
@@ -19,9 +19,11 @@ import (
 
 // Login represents a login to a gitea server, you even could add multiple logins for one gitea server
 type Login struct {
-	Name    string `yaml:"name"`
-	URL     string `yaml:"url"`
-	Token   string `yaml:"token"`
+	Name  string `yaml:"name"`
+	URL   string `yaml:"url"`
+	Token string `yaml:"token"`
+	// TokenID is set, if the token was created by tea
+	TokenID int64  `yaml:"token_id,omitempty"`
 	Default bool   `yaml:"default"`
 	SSHHost string `yaml:"ssh_host"`
 	// optional path to the private key
@@ -31,6 +33,10 @@ type Login struct {
 	User string `yaml:"user"`
 	// Created is auto created unix timestamp
 	Created int64 `yaml:"created"`
+	// Sudo is the user to act as, which requires an admin login. Not persisted
+	Sudo string `yaml:"-"`
+	// OTP is a one-time password for two-factor authentication. Not persisted
+	OTP string `yaml:"-"`
 }
 
 // GetLogins return all login available by config
@@ -161,21 +167,31 @@ func AddLogin(login *Login) error {
 	return saveConfig()
 }
 
+// UpdateLogin replaces the login with the same name in the config
+func UpdateLogin(login *Login) error {
+	if err := loadConfig(); err != nil {
+		return err
+	}
+
+	for i := range config.Logins {
+		if config.Logins[i].Name == login.Name {
+			config.Logins[i] = *login
+			return saveConfig()
+		}
+	}
+	return fmt.Errorf("can not update login '%s', does not exist", login.Name)
+}
+
 // Client returns a client to operate Gitea API. You may provide additional modifiers
 // for the client like gitea.SetBasicAuth() for customization
 func (l *Login) Client(options ...func(*gitea.Client)) *gitea.Client {
-	httpClient := &http.Client{}
-	if l.Insecure {
-		cookieJar, _ := cookiejar.New(nil)
-
-		httpClient = &http.Client{
-			Jar: cookieJar,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-			}}
+	options = append(options, gitea.SetToken(l.Token), gitea.SetHTTPClient(l.HTTPClient()))
+	if len(l.Sudo) != 0 {
+		options = append(options, gitea.SetSudo(l.Sudo))
+	}
+	if len(l.OTP) != 0 {
+		options = append(options, gitea.SetOTP(l.OTP))
 	}
-
-	options = append(options, gitea.SetToken(l.Token), gitea.SetHTTPClient(httpClient))
 
 	client, err := gitea.NewClient(l.URL, options...)
 	if err != nil {
@@ -184,6 +200,20 @@ func (l *Login) Client(options ...func(*gitea.Client)) *gitea.Client {
 	return client
 }
 
+// HTTPClient returns the http client used to connect to the server of the login
+func (l *Login) HTTPClient() *http.Client {
+	if !l.Insecure {
+		return &http.Client{}
+	}
+
+	cookieJar, _ := cookiejar.New(nil)
+	return &http.Client{
+		Jar: cookieJar,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}}
+}
+
 // GetSSHHost returns SSH host name
 func (l *Login) GetSSHHost() string {
 	if l.SSHHost != "" {
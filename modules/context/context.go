@@ -131,7 +131,20 @@ and then run your command again.`)
 			}
 			os.Exit(1)
 		}
-		fmt.Printf("NOTE: no gitea login detected, falling back to login '%s'\n", c.Login.Name)
+		// printed to stderr, to not corrupt machine readable output
+		fmt.Fprintf(os.Stderr, "NOTE: no gitea login detected, falling back to login '%s'\n", c.Login.Name)
+	}
+
+	// apply the global flags, on a copy to not leak them into the config
+	if sudo, otp := ctx.String("sudo"), ctx.String("otp"); len(sudo) != 0 || len(otp) != 0 {
+		login := *c.Login
+		login.OTP = otp
+		if len(sudo) != 0 {
+			// commands defaulting to the own user now act on the impersonated one
+			login.Sudo = sudo
+			login.User = sudo
+		}
+		c.Login = &login
 	}
 
 	// parse reposlug (owner falling back to login owner if reposlug contains only repo name)
@@ -225,3 +225,49 @@ func (r TeaRepo) TeaGetCurrentBranchName() (string, error) {
 
 	return localHead.Name().Short(), nil
 }
+
+// TeaTrackedBranchNames returns the names of all local branches, for which a
+// remote branch of the same name exists on any remote.
+func (r TeaRepo) TeaTrackedBranchNames() ([]string, error) {
+	iter, err := r.References()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	local := make(map[string]bool)
+	remote := make(map[string]bool)
+	err = iter.ForEach(func(ref *git_plumbing.Reference) error {
+		switch n := ref.Name(); {
+		case n.IsBranch():
+			local[n.Short()] = true
+		case n.IsRemote():
+			if names := strings.SplitN(n.Short(), "/", 2); len(names) == 2 {
+				remote[names[1]] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range local {
+		if remote[name] {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// TeaCountDivergence returns the number of commits reachable from head but not
+// from base (ahead), and vice versa (behind). Both commits must exist locally.
+func (r TeaRepo) TeaCountDivergence(base, head string) (ahead, behind int, err error) {
+	out, err := r.runGit("rev-list", "--left-right", "--count", base+"..."+head)
+	if err != nil {
+		return 0, 0, err
+	}
+	_, err = fmt.Sscanf(out, "%d %d", &behind, &ahead)
+	return ahead, behind, err
+}
@@ -0,0 +1,49 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// TeaRoot returns the root directory of the repo's worktree
+func (r TeaRepo) TeaRoot() (string, error) {
+	tree, err := r.Worktree()
+	if err != nil {
+		return "", err
+	}
+	return tree.Filesystem.Root(), nil
+}
+
+// runGit executes the git binary in the worktree of the repo, for operations
+// that are not supported by go-git. Returns stdout of the command.
+func (r TeaRepo) runGit(args ...string) (string, error) {
+	root, err := r.TeaRoot()
+	if err != nil {
+		return "", err
+	}
+	return runGitIn(root, args...)
+}
+
+// runGitIn executes the git binary in the given directory. Returns stdout of the command.
+func runGitIn(dir string, args ...string) (string, error) {
+	executable, err := exec.LookPath("git")
+	if err != nil {
+		return "", fmt.Errorf("this operation requires a local git installation: %s", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(executable, args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	git_plumbing "github.com/go-git/go-git/v5/plumbing"
+)
+
+// TeaMergeLocal merges headRef into baseRef, and stores the result in the new
+// local branch targetBranch. The merge happens in a temporary linked worktree,
+// so the current worktree is left untouched. Supported styles are merge, squash,
+// rebase and rebase-merge. If sign is set, the resulting commits are GPG signed.
+// targetBranch must not exist yet. It is deleted again if the merge fails,
+// otherwise the caller is responsible to delete it.
+func (r TeaRepo) TeaMergeLocal(targetBranch string, baseRef, headRef git_plumbing.ReferenceName, style, message string, sign bool) (err error) {
+	switch style {
+	case "merge", "squash", "rebase", "rebase-merge":
+	default:
+		return fmt.Errorf("unknown merge style '%s'", style)
+	}
+
+	if _, err = r.Reference(git_plumbing.NewBranchReferenceName(targetBranch), false); err == nil {
+		return fmt.Errorf("branch '%s' already exists, remove it to merge locally", targetBranch)
+	}
+
+	tmpDir, err := ioutil.TempDir("", "tea-merge-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	dir := filepath.Join(tmpDir, "worktree")
+
+	// rebasing styles start from the head branch, the others from the base branch
+	startRef := baseRef
+	if strings.HasPrefix(style, "rebase") {
+		startRef = headRef
+	}
+	if _, err = r.runGit("worktree", "add", "-b", targetBranch, dir, startRef.String()); err != nil {
+		return err
+	}
+	// registered first, so it runs after the worktree holding the branch is removed
+	defer func() {
+		if err != nil {
+			r.runGit("branch", "-D", targetBranch)
+		}
+	}()
+	defer func() {
+		if _, rmErr := r.runGit("worktree", "remove", "--force", dir); rmErr != nil && err == nil {
+			err = rmErr
+		}
+	}()
+
+	var signArgs []string
+	if sign {
+		signArgs = []string{"--gpg-sign"}
+	}
+	git := func(args ...string) error {
+		_, err := runGitIn(dir, args...)
+		return err
+	}
+
+	switch style {
+	case "merge":
+		args := append([]string{"merge", "--no-ff", "--no-edit", "-m", message}, signArgs...)
+		if err = git(append(args, headRef.String())...); err != nil {
+			git("merge", "--abort")
+		}
+
+	case "squash":
+		if err = git("merge", "--squash", headRef.String()); err != nil {
+			git("merge", "--abort")
+			break
+		}
+		err = git(append([]string{"commit", "--no-edit", "-m", message}, signArgs...)...)
+
+	case "rebase", "rebase-merge":
+		if err = git(append([]string{"rebase"}, append(signArgs, baseRef.String())...)...); err != nil {
+			git("rebase", "--abort")
+			break
+		}
+		if style == "rebase" {
+			break
+		}
+		var rebased string
+		if rebased, err = runGitIn(dir, "rev-parse", "HEAD"); err != nil {
+			break
+		}
+		if err = git("reset", "--hard", baseRef.String()); err != nil {
+			break
+		}
+		args := append([]string{"merge", "--no-ff", "--no-edit", "-m", message}, signArgs...)
+		err = git(append(args, strings.TrimSpace(rebased))...)
+	}
+
+	return err
+}
@@ -0,0 +1,82 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"os/exec"
+	"strings"
+
+	git_plumbing "github.com/go-git/go-git/v5/plumbing"
+)
+
+// Worktree describes a linked worktree of a repository
+type Worktree struct {
+	Path   string
+	Head   string
+	Branch string // short branch name, empty if HEAD is detached
+}
+
+// TeaAddWorktree creates a linked worktree at path with the given ref checked out.
+// If the ref is no local branch, HEAD of the new worktree is detached.
+func (r TeaRepo) TeaAddWorktree(path string, ref git_plumbing.ReferenceName) error {
+	args := []string{"worktree", "add"}
+	if !ref.IsBranch() {
+		args = append(args, "--detach")
+	}
+	args = append(args, path, ref.Short())
+	_, err := r.runGit(args...)
+	return err
+}
+
+// TeaRemoveWorktree removes the linked worktree at path. Fails if the worktree
+// contains uncommitted changes.
+func (r TeaRepo) TeaRemoveWorktree(path string) error {
+	_, err := r.runGit("worktree", "remove", path)
+	return err
+}
+
+// TeaWorktrees lists the linked worktrees of the repo, excluding the main worktree.
+func (r TeaRepo) TeaWorktrees() ([]Worktree, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		// linked worktrees can't be created without a git installation
+		return nil, nil
+	}
+	out, err := r.runGit("worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	trees := parseWorktreeList(out)
+	if len(trees) == 0 {
+		return trees, nil
+	}
+	// the main worktree is always listed first
+	return trees[1:], nil
+}
+
+// parseWorktreeList parses the output of `git worktree list --porcelain`
+func parseWorktreeList(out string) []Worktree {
+	var trees []Worktree
+	for _, block := range strings.Split(strings.TrimSpace(out), "\n\n") {
+		var tree Worktree
+		for _, line := range strings.Split(block, "\n") {
+			fields := strings.SplitN(line, " ", 2)
+			if len(fields) != 2 {
+				continue
+			}
+			switch fields[0] {
+			case "worktree":
+				tree.Path = fields[1]
+			case "HEAD":
+				tree.Head = fields[1]
+			case "branch":
+				tree.Branch = git_plumbing.ReferenceName(fields[1]).Short()
+			}
+		}
+		if tree.Path != "" {
+			trees = append(trees, tree)
+		}
+	}
+	return trees
+}
@@ -0,0 +1,38 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWorktreeList(t *testing.T) {
+	const out = `worktree /home/user/tea
+HEAD 5c1fa2d1b0f1f7c6cb9c1c4d3e3e3b5f3c3b0a11
+branch refs/heads/main
+
+worktree /home/user/tea-pull-42
+HEAD 0a1b2c3d4e5f60718293a4b5c6d7e8f901234567
+branch refs/heads/pulls/42
+
+worktree /home/user/tea-pull-43
+HEAD 1111111111111111111111111111111111111111
+detached
+
+`
+	trees := parseWorktreeList(out)
+	assert.Len(t, trees, 3)
+	assert.Equal(t, "/home/user/tea", trees[0].Path)
+	assert.Equal(t, "main", trees[0].Branch)
+	assert.Equal(t, "/home/user/tea-pull-42", trees[1].Path)
+	assert.Equal(t, "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567", trees[1].Head)
+	assert.Equal(t, "pulls/42", trees[1].Branch)
+	assert.Equal(t, "/home/user/tea-pull-43", trees[2].Path)
+	assert.Equal(t, "", trees[2].Branch)
+
+	assert.Len(t, parseWorktreeList(""), 0)
+}
@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"strings"
 
+	"code.gitea.io/tea/modules/config"
 	"code.gitea.io/tea/modules/task"
 
+	"code.gitea.io/sdk/gitea"
 	"github.com/AlecAivazis/survey/v2"
 )
 
@@ -87,5 +89,47 @@ func CreateLogin() error {
 		}
 	}
 
-	return task.CreateLogin(name, token, user, passwd, sshKey, giteaURL, insecure)
+	login, err := task.CreateLogin(name, token, user, passwd, "", sshKey, giteaURL, insecure)
+	if err == task.ErrOTPRequired {
+		var otp string
+		if otp, err = PromptOTP(); err != nil {
+			return err
+		}
+		login, err = task.CreateLogin(name, token, user, passwd, otp, sshKey, giteaURL, insecure)
+	}
+	if err != nil {
+		return err
+	}
+	return SetupLoginKeys(login)
+}
+
+// PromptOTP asks for a one-time password for two-factor authentication
+func PromptOTP() (otp string, err error) {
+	promptI := &survey.Input{Message: "One-time password (2FA): "}
+	err = survey.AskOne(promptI, &otp, survey.WithValidator(survey.Required))
+	return strings.TrimSpace(otp), err
+}
+
+// BasicAuthClient asks for the password of user, if none is given, and for a
+// one-time password, if required, to return a client using basic auth
+func BasicAuthClient(login *config.Login, user, pass string) (*gitea.Client, error) {
+	var err error
+	if len(pass) == 0 {
+		if IsStdinPiped() {
+			return nil, fmt.Errorf("Must specify a password, authentication with username & password is required")
+		}
+		if pass, err = PromptPassword(user); err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := task.BasicAuthClient(login, user, pass)
+	if err == task.ErrOTPRequired && !IsStdinPiped() {
+		withOTP := *login
+		if withOTP.OTP, err = PromptOTP(); err != nil {
+			return nil, err
+		}
+		client, err = task.BasicAuthClient(&withOTP, user, pass)
+	}
+	return client, err
 }
@@ -0,0 +1,108 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package interact
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/task"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+const (
+	keyOptionGenerate = "Generate a new ed25519 key"
+	keyOptionSkip     = "Skip"
+)
+
+// SetupLoginKeys offers to add a local SSH key & GPG key to the account of
+// the login, if it has none of them configured yet. Failures are printed as
+// warnings, as the login itself was already created.
+func SetupLoginKeys(login *config.Login) error {
+	if len(login.SSHKey) == 0 {
+		if err := setupSSHKey(login); err != nil {
+			fmt.Printf("Warning: could not add a SSH key: %s\n", err)
+		}
+	}
+	if err := setupGPGKey(login); err != nil {
+		fmt.Printf("Warning: could not add a GPG key: %s\n", err)
+	}
+	return nil
+}
+
+func setupSSHKey(login *config.Login) error {
+	keys, err := task.LocalSSHKeys()
+	if err != nil {
+		return err
+	}
+
+	options := append(keys, keyOptionGenerate, keyOptionSkip)
+	var selected string
+	prompt := &survey.Select{
+		Message: "No SSH key of this machine is known to the server. Add one for SSH clones?",
+		Options: options,
+		Default: options[0],
+	}
+	if err = survey.AskOne(prompt, &selected); err != nil {
+		return err
+	}
+
+	switch selected {
+	case keyOptionSkip:
+		return nil
+	case keyOptionGenerate:
+		var path string
+		promptI := &survey.Input{Message: "Path of the new private key:", Default: "~/.ssh/id_ed25519"}
+		if err = survey.AskOne(promptI, &path, survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+		err = task.GenerateAndUploadSSHKey(login, strings.TrimSpace(path))
+	default:
+		err = task.UploadSSHKey(login, selected)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Added SSH key %s to the account of %s\n", login.SSHKey, login.User)
+	return nil
+}
+
+func setupGPGKey(login *config.Login) error {
+	hasKey, err := task.HasGPGKey(login)
+	if err != nil || hasKey {
+		return err
+	}
+	keys, err := task.LocalGPGKeys()
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+
+	options := make([]string, 0, len(keys)+1)
+	for _, k := range keys {
+		options = append(options, fmt.Sprintf("%s %s", k.ID, strings.Join(k.UserIDs, ", ")))
+	}
+	options = append(options, keyOptionSkip)
+
+	var selected int
+	prompt := &survey.Select{
+		Message: "No GPG key is known to the server. Add one to verify signed commits?",
+		Options: options,
+	}
+	if err = survey.AskOne(prompt, &selected); err != nil {
+		return err
+	}
+	if selected == len(keys) {
+		return nil
+	}
+
+	if err = task.UploadGPGKey(login, keys[selected].ID); err != nil {
+		return err
+	}
+	fmt.Printf("Added GPG key %s to the account of %s\n", keys[selected].ID, login.User)
+	return nil
+}
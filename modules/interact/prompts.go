@@ -44,6 +44,20 @@ func PromptPassword(name string) (pass string, err error) {
 	return
 }
 
+// PromptConfirm asks a yes/no question and blocks until input was made.
+func PromptConfirm(message string, defaultVal bool) (confirmed bool, err error) {
+	prompt := &survey.Confirm{Message: message, Default: defaultVal}
+	err = survey.AskOne(prompt, &confirmed)
+	return
+}
+
+// PromptInput asks for a single line of text and blocks until input was made.
+func PromptInput(message string) (value string, err error) {
+	prompt := &survey.Input{Message: message}
+	err = survey.AskOne(prompt, &value)
+	return
+}
+
 // promptRepoSlug interactively prompts for a Gitea repository or returns the current one
 func promptRepoSlug(defaultOwner, defaultRepo string) (owner, repo string, err error) {
 	prompt := "Target repo:"
@@ -0,0 +1,156 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package interact
+
+import (
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/AlecAivazis/survey/v2"
+)
+
+var (
+	repoUnitIssues = "Issues"
+	repoUnitWiki   = "Wiki"
+	repoUnitPulls  = "Pull Requests"
+	repoUnitProjs  = "Projects"
+
+	mergeStyleOptions = []string{
+		string(gitea.MergeStyleMerge),
+		string(gitea.MergeStyleRebase),
+		string(gitea.MergeStyleRebaseMerge),
+		string(gitea.MergeStyleSquash),
+	}
+)
+
+// EditRepo interactively edits the settings of a repo, using its current values as defaults
+func EditRepo(login *config.Login, owner, repo string) error {
+	owner, repo, err := promptRepoSlug(owner, repo)
+	if err != nil {
+		return err
+	}
+
+	r, _, err := login.Client().GetRepo(owner, repo)
+	if err != nil {
+		return err
+	}
+	var opts gitea.EditRepoOption
+
+	// name, description, website, default branch
+	if opts.Name, err = promptChangedString("Name:", r.Name); err != nil {
+		return err
+	}
+	if opts.Description, err = promptChangedString("Description:", r.Description); err != nil {
+		return err
+	}
+	if opts.Website, err = promptChangedString("Website:", r.Website); err != nil {
+		return err
+	}
+	if !r.Empty {
+		if opts.DefaultBranch, err = promptChangedString("Default branch:", r.DefaultBranch); err != nil {
+			return err
+		}
+	}
+
+	// visibility
+	if opts.Private, err = promptChangedBool("Private?", r.Private); err != nil {
+		return err
+	}
+	if opts.Template, err = promptChangedBool("Template?", r.Template); err != nil {
+		return err
+	}
+
+	// units
+	var units []string
+	unitOptions := []string{repoUnitIssues, repoUnitWiki, repoUnitPulls, repoUnitProjs}
+	currentUnits := make([]string, 0, len(unitOptions))
+	for i, enabled := range []bool{r.HasIssues, r.HasWiki, r.HasPullRequests, r.HasProjects} {
+		if enabled {
+			currentUnits = append(currentUnits, unitOptions[i])
+		}
+	}
+	promptU := &survey.MultiSelect{Message: "Enabled units:", Options: unitOptions, Default: currentUnits, VimMode: true}
+	if err = survey.AskOne(promptU, &units); err != nil {
+		return err
+	}
+	opts.HasIssues = changedBool(utils.Contains(units, repoUnitIssues), r.HasIssues)
+	opts.HasWiki = changedBool(utils.Contains(units, repoUnitWiki), r.HasWiki)
+	opts.HasPullRequests = changedBool(utils.Contains(units, repoUnitPulls), r.HasPullRequests)
+	opts.HasProjects = changedBool(utils.Contains(units, repoUnitProjs), r.HasProjects)
+
+	// merge styles
+	if utils.Contains(units, repoUnitPulls) {
+		var styles []string
+		currentStyles := make([]string, 0, len(mergeStyleOptions))
+		for i, allowed := range []bool{r.AllowMerge, r.AllowRebase, r.AllowRebaseMerge, r.AllowSquash} {
+			if allowed {
+				currentStyles = append(currentStyles, mergeStyleOptions[i])
+			}
+		}
+		promptS := &survey.MultiSelect{Message: "Allowed merge styles:", Options: mergeStyleOptions, Default: currentStyles, VimMode: true}
+		if err = survey.AskOne(promptS, &styles, survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+		opts.AllowMerge = changedBool(utils.Contains(styles, string(gitea.MergeStyleMerge)), r.AllowMerge)
+		opts.AllowRebase = changedBool(utils.Contains(styles, string(gitea.MergeStyleRebase)), r.AllowRebase)
+		opts.AllowRebaseMerge = changedBool(utils.Contains(styles, string(gitea.MergeStyleRebaseMerge)), r.AllowRebaseMerge)
+		opts.AllowSquash = changedBool(utils.Contains(styles, string(gitea.MergeStyleSquash)), r.AllowSquash)
+
+		var defaultStyle string
+		currentDefault := string(r.DefaultMergeStyle)
+		if !utils.Contains(styles, currentDefault) {
+			currentDefault = styles[0]
+		}
+		promptD := &survey.Select{Message: "Default merge style:", Options: styles, Default: currentDefault, VimMode: true}
+		if err = survey.AskOne(promptD, &defaultStyle); err != nil {
+			return err
+		}
+		if defaultStyle != string(r.DefaultMergeStyle) {
+			style := gitea.MergeStyle(defaultStyle)
+			opts.DefaultMergeStyle = &style
+		}
+	}
+
+	if r.Mirror {
+		if opts.MirrorInterval, err = promptChangedString("Mirror interval:", r.MirrorInterval); err != nil {
+			return err
+		}
+	}
+	if opts.Archived, err = promptChangedBool("Archived?", r.Archived); err != nil {
+		return err
+	}
+
+	return task.EditRepo(login, owner, repo, opts)
+}
+
+// promptChangedString asks for a value, and returns it only if it differs from the current value
+func promptChangedString(message, current string) (*string, error) {
+	var value string
+	if err := survey.AskOne(&survey.Input{Message: message, Default: current}, &value); err != nil {
+		return nil, err
+	}
+	if value == current {
+		return nil, nil
+	}
+	return &value, nil
+}
+
+// promptChangedBool asks for a value, and returns it only if it differs from the current value
+func promptChangedBool(message string, current bool) (*bool, error) {
+	value, err := PromptConfirm(message, current)
+	if err != nil {
+		return nil, err
+	}
+	return changedBool(value, current), nil
+}
+
+func changedBool(value, current bool) *bool {
+	if value == current {
+		return nil
+	}
+	return &value
+}
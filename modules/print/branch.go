@@ -0,0 +1,143 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package print
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// BranchDetails prints a branch formatted to stdout
+func BranchDetails(branch *gitea.Branch) {
+	title := "# " + branch.Name
+	if branch.Protected {
+		title += " (protected)"
+	}
+	title += "\n"
+
+	var commit string
+	if branch.Commit != nil {
+		commit = fmt.Sprintf("- Commit:\t[%s](%s) %s\n- Author:\t%s\n- Date:\t%s\n",
+			formatSha(branch.Commit.ID),
+			branch.Commit.URL,
+			formatCommitTitle(branch.Commit.Message),
+			formatCommitAuthor(branch.Commit),
+			FormatTime(branch.Commit.Timestamp),
+		)
+	}
+
+	var protection string
+	if branch.Protected {
+		protection = fmt.Sprintf("- Protection Rule:\t%s\n- Required Approvals:\t%d\n",
+			branch.EffectiveBranchProtectionName,
+			branch.RequiredApprovals,
+		)
+		if branch.EnableStatusCheck {
+			protection += fmt.Sprintf("- Status Checks:\t%s\n", strings.Join(branch.StatusCheckContexts, ", "))
+		}
+	}
+
+	perm := fmt.Sprintf("- Can Push:\t%s\n- Can Merge:\t%s\n",
+		formatBoolean(branch.UserCanPush, true),
+		formatBoolean(branch.UserCanMerge, true),
+	)
+
+	outputMarkdown(fmt.Sprintf("%s\n%s%s%s", title, commit, protection, perm), "")
+}
+
+// BranchesList prints a listing of branches. ahead & behind map branch names
+// to their commit count relative to the default branch, and may be nil.
+func BranchesList(branches []*gitea.Branch, ahead, behind map[string]int, output string, fields []string) {
+	var printables = make([]printable, len(branches))
+	for i, b := range branches {
+		printables[i] = &printableBranch{b, ahead, behind}
+	}
+	t := tableFromItems(fields, printables, isMachineReadable(output))
+	t.print(output)
+}
+
+// BranchFields are the available fields to print with BranchesList()
+var BranchFields = []string{
+	"name",
+	"sha",
+	"message",
+	"author",
+	"updated",
+	"protected",
+	"user-can-push",
+	"user-can-merge",
+	"ahead",
+	"behind",
+}
+
+type printableBranch struct {
+	*gitea.Branch
+	ahead, behind map[string]int
+}
+
+func (x printableBranch) FormatField(field string, machineReadable bool) string {
+	switch field {
+	case "name":
+		return x.Name
+	case "protected":
+		return formatBoolean(x.Protected, !machineReadable)
+	case "user-can-push":
+		return formatBoolean(x.UserCanPush, !machineReadable)
+	case "user-can-merge":
+		return formatBoolean(x.UserCanMerge, !machineReadable)
+	case "ahead":
+		return formatCount(x.ahead, x.Name)
+	case "behind":
+		return formatCount(x.behind, x.Name)
+	}
+
+	if x.Commit == nil {
+		return ""
+	}
+	switch field {
+	case "sha":
+		if machineReadable {
+			return x.Commit.ID
+		}
+		return formatSha(x.Commit.ID)
+	case "message":
+		return formatCommitTitle(x.Commit.Message)
+	case "author":
+		return formatCommitAuthor(x.Commit)
+	case "updated":
+		return FormatTime(x.Commit.Timestamp)
+	}
+	return ""
+}
+
+func formatCount(counts map[string]int, key string) string {
+	if c, ok := counts[key]; ok {
+		return fmt.Sprintf("%d", c)
+	}
+	return ""
+}
+
+func formatSha(sha string) string {
+	if len(sha) > 10 {
+		return sha[:10]
+	}
+	return sha
+}
+
+func formatCommitTitle(message string) string {
+	return strings.TrimSpace(strings.SplitN(message, "\n", 2)[0])
+}
+
+func formatCommitAuthor(commit *gitea.PayloadCommit) string {
+	if commit.Author == nil {
+		return ""
+	}
+	if len(commit.Author.UserName) != 0 {
+		return commit.Author.UserName
+	}
+	return commit.Author.Name
+}
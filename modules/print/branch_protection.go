@@ -0,0 +1,90 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package print
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// BranchProtectionDetails prints a branch protection rule formatted to stdout
+func BranchProtectionDetails(bp *gitea.BranchProtection) {
+	out := fmt.Sprintf("# %s\n\n", bp.BranchName)
+
+	out += fmt.Sprintf("- Push:\t%s\n", formatProtectionPush(bp))
+	if bp.EnablePushWhitelist {
+		out += fmt.Sprintf("  - Users:\t%s\n  - Teams:\t%s\n  - Deploy Keys:\t%s\n",
+			strings.Join(bp.PushWhitelistUsernames, ", "),
+			strings.Join(bp.PushWhitelistTeams, ", "),
+			formatBoolean(bp.PushWhitelistDeployKeys, true),
+		)
+	}
+	if bp.EnableMergeWhitelist {
+		out += fmt.Sprintf("- Merge Whitelist:\n  - Users:\t%s\n  - Teams:\t%s\n",
+			strings.Join(bp.MergeWhitelistUsernames, ", "),
+			strings.Join(bp.MergeWhitelistTeams, ", "),
+		)
+	}
+	if bp.EnableStatusCheck {
+		out += fmt.Sprintf("- Status Checks:\t%s\n", strings.Join(bp.StatusCheckContexts, ", "))
+	}
+
+	out += fmt.Sprintf("- Required Approvals:\t%d\n", bp.RequiredApprovals)
+	if bp.EnableApprovalsWhitelist {
+		out += fmt.Sprintf("  - Users:\t%s\n  - Teams:\t%s\n",
+			strings.Join(bp.ApprovalsWhitelistUsernames, ", "),
+			strings.Join(bp.ApprovalsWhitelistTeams, ", "),
+		)
+	}
+	out += fmt.Sprintf("- Dismiss Stale Approvals:\t%s\n", formatBoolean(bp.DismissStaleApprovals, true))
+	out += fmt.Sprintf("- Block On Rejected Reviews:\t%s\n", formatBoolean(bp.BlockOnRejectedReviews, true))
+	out += fmt.Sprintf("- Block On Official Review Requests:\t%s\n", formatBoolean(bp.BlockOnOfficialReviewRequests, true))
+	out += fmt.Sprintf("- Block On Outdated Branch:\t%s\n", formatBoolean(bp.BlockOnOutdatedBranch, true))
+	out += fmt.Sprintf("- Require Signed Commits:\t%s\n", formatBoolean(bp.RequireSignedCommits, true))
+	if len(bp.ProtectedFilePatterns) != 0 {
+		out += fmt.Sprintf("- Protected Files:\t%s\n", bp.ProtectedFilePatterns)
+	}
+
+	outputMarkdown(out, "")
+}
+
+// BranchProtectionsList prints a listing of branch protection rules
+func BranchProtectionsList(protections []*gitea.BranchProtection, output string) {
+	t := tableWithHeader(
+		"Branch",
+		"Push",
+		"Approvals",
+		"Status Checks",
+		"Dismiss Stale",
+		"Signed Commits",
+		"Updated",
+	)
+
+	machineReadable := isMachineReadable(output)
+	for _, bp := range protections {
+		t.addRow(
+			bp.BranchName,
+			formatProtectionPush(bp),
+			fmt.Sprint(bp.RequiredApprovals),
+			strings.Join(bp.StatusCheckContexts, " "),
+			formatBoolean(bp.DismissStaleApprovals, !machineReadable),
+			formatBoolean(bp.RequireSignedCommits, !machineReadable),
+			FormatTime(bp.Updated),
+		)
+	}
+	t.print(output)
+}
+
+func formatProtectionPush(bp *gitea.BranchProtection) string {
+	if !bp.EnablePush {
+		return "disabled"
+	}
+	if bp.EnablePushWhitelist {
+		return "whitelist"
+	}
+	return "enabled"
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package print
+
+import (
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// ContentsList prints a listing of the entries of a repo directory
+func ContentsList(contents []*gitea.ContentsResponse, output string) {
+	t := tableWithHeader(
+		"Type",
+		"Path",
+		"Size",
+	)
+
+	machineReadable := isMachineReadable(output)
+	for _, c := range contents {
+		t.addRow(c.Type, c.Path, formatEntrySize(c.Type == "file", c.Size, machineReadable))
+	}
+	t.print(output)
+}
+
+// TreeList prints a listing of the entries of a git tree
+func TreeList(entries []gitea.GitEntry, output string) {
+	t := tableWithHeader(
+		"Type",
+		"Path",
+		"Size",
+	)
+
+	machineReadable := isMachineReadable(output)
+	for _, e := range entries {
+		entryType := e.Type
+		switch e.Type {
+		case "blob":
+			entryType = "file"
+		case "tree":
+			entryType = "dir"
+		case "commit":
+			entryType = "submodule"
+		}
+		t.addRow(entryType, e.Path, formatEntrySize(e.Type == "blob", e.Size, machineReadable))
+	}
+	t.print(output)
+}
+
+// FileContent prints the content of a file, optionally rendering it as markdown
+func FileContent(content []byte, render bool) {
+	if render {
+		outputMarkdown(string(content), "")
+		return
+	}
+	fmt.Printf("%s", content)
+}
+
+func formatEntrySize(isFile bool, bytes int64, machineReadable bool) string {
+	if !isFile {
+		return ""
+	}
+	if machineReadable {
+		return fmt.Sprint(bytes)
+	}
+	if bytes < 1024 {
+		return fmt.Sprintf("%d b", bytes)
+	}
+	return formatSize(bytes / 1024)
+}
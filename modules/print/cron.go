@@ -0,0 +1,33 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package print
+
+import (
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// CronTasksList prints a listing of cron tasks
+func CronTasksList(tasks []*gitea.CronTask, output string) {
+	t := tableWithHeader(
+		"Name",
+		"Schedule",
+		"Next",
+		"Previous",
+		"Executions",
+	)
+
+	for _, task := range tasks {
+		t.addRow(
+			task.Name,
+			task.Schedule,
+			FormatTime(task.Next),
+			FormatTime(task.Prev),
+			fmt.Sprintf("%d", task.ExecTimes),
+		)
+	}
+	t.print(output)
+}
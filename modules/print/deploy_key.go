@@ -0,0 +1,34 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package print
+
+import (
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// DeployKeysList prints a listing of deploy keys
+func DeployKeysList(keys []*gitea.DeployKey, output string) {
+	t := tableWithHeader(
+		"ID",
+		"Title",
+		"Fingerprint",
+		"Read Only",
+		"Created",
+	)
+
+	machineReadable := isMachineReadable(output)
+	for _, k := range keys {
+		t.addRow(
+			fmt.Sprint(k.ID),
+			k.Title,
+			k.Fingerprint,
+			formatBoolean(k.ReadOnly, !machineReadable),
+			FormatTime(k.Created),
+		)
+	}
+	t.print(output)
+}
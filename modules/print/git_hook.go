@@ -0,0 +1,87 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package print
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// GitHooksList prints a listing of server side git hooks
+func GitHooksList(hooks []*gitea.GitHook, output string) {
+	t := tableWithHeader(
+		"Name",
+		"Active",
+	)
+
+	machineReadable := isMachineReadable(output)
+	for _, h := range hooks {
+		t.addRow(h.Name, formatBoolean(h.IsActive, !machineReadable))
+	}
+	t.print(output)
+}
+
+// GitHookContent prints the content of a git hook to stdout
+func GitHookContent(hook *gitea.GitHook) {
+	fmt.Print(hook.Content)
+	if len(hook.Content) != 0 && !strings.HasSuffix(hook.Content, "\n") {
+		fmt.Println()
+	}
+}
+
+// TextDiff prints a line based diff of two texts to stdout
+func TextDiff(fromName, toName, from, to string) {
+	fmt.Printf("--- %s\n+++ %s\n", fromName, toName)
+	for _, line := range diffLines(from, to) {
+		fmt.Println(line)
+	}
+}
+
+// diffLines returns all lines of both texts prefixed with ' ', '-' or '+'.
+// The line mode of the vendored diffmatchpatch is broken, so each line is
+// mapped to a rune of the private use area, and the runes are diffed instead.
+func diffLines(from, to string) []string {
+	const firstRune = 0xE000
+	var lines []string
+	runes := map[string]rune{}
+	toRunes := func(text string) []rune {
+		var result []rune
+		for _, line := range splitLines(text) {
+			r, ok := runes[line]
+			if !ok {
+				r = rune(firstRune + len(lines))
+				runes[line] = r
+				lines = append(lines, line)
+			}
+			result = append(result, r)
+		}
+		return result
+	}
+
+	var result []string
+	for _, d := range diffmatchpatch.New().DiffMainRunes(toRunes(from), toRunes(to), false) {
+		prefix := " "
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			prefix = "-"
+		case diffmatchpatch.DiffInsert:
+			prefix = "+"
+		}
+		for _, r := range d.Text {
+			result = append(result, prefix+lines[r-firstRune])
+		}
+	}
+	return result
+}
+
+func splitLines(text string) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
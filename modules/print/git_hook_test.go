@@ -0,0 +1,28 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package print
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	a := "#!/bin/sh\necho a\nexit 0\n"
+	b := "#!/bin/sh\necho b\necho c\nexit 0\n"
+	assert.Equal(t, []string{
+		" #!/bin/sh",
+		"-echo a",
+		"+echo b",
+		"+echo c",
+		" exit 0",
+	}, diffLines(a, b))
+
+	assert.Equal(t, []string{"+x"}, diffLines("", "x\n"))
+	assert.Equal(t, []string{" a", "-a", "+b", " b"}, diffLines("a\na\nb\n", "a\nb\nb\n"))
+	// a missing newline at the end of the text doesn't count as change
+	assert.Equal(t, []string{" x", "+y"}, diffLines("x", "x\ny"))
+}
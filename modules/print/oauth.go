@@ -0,0 +1,68 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package print
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// OauthAppDetails prints an oauth2 application in detail
+func OauthAppDetails(app *gitea.Oauth2) {
+	out := fmt.Sprintf("# %d: %s\n\n", app.ID, app.Name)
+
+	out += fmt.Sprintf("- Client ID:\t%s\n", app.ClientID)
+	out += fmt.Sprintf("- Redirect URIs:\t%s\n", strings.Join(app.RedirectURIs, ", "))
+	out += fmt.Sprintf("- Created:\t%s\n", FormatTime(app.Created))
+
+	outputMarkdown(out, "")
+}
+
+// OauthAppsList prints a listing of oauth2 applications
+func OauthAppsList(apps []*gitea.Oauth2, output string) {
+	t := tableWithHeader(
+		"ID",
+		"Name",
+		"Client ID",
+		"Redirect URIs",
+		"Created",
+	)
+
+	for _, app := range apps {
+		t.addRow(
+			fmt.Sprint(app.ID),
+			app.Name,
+			app.ClientID,
+			strings.Join(app.RedirectURIs, " "),
+			FormatTime(app.Created),
+		)
+	}
+	t.print(output)
+}
+
+// OauthAppCredentials prints the client credentials of an oauth2 application.
+// Without output format, they are printed as KEY=value lines to be used as env file.
+func OauthAppCredentials(app *gitea.Oauth2, output string) {
+	if len(output) == 0 || output == "env" {
+		fmt.Printf("CLIENT_ID=%s\nCLIENT_SECRET=%s\n", app.ClientID, app.ClientSecret)
+		return
+	}
+
+	t := tableWithHeader(
+		"ID",
+		"Name",
+		"Client ID",
+		"Client Secret",
+	)
+	t.addRow(
+		fmt.Sprint(app.ID),
+		app.Name,
+		app.ClientID,
+		app.ClientSecret,
+	)
+	t.print(output)
+}
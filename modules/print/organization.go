@@ -10,16 +10,36 @@ import (
 	"code.gitea.io/sdk/gitea"
 )
 
-// OrganizationDetails prints details of an org with formatting
-func OrganizationDetails(org *gitea.Organization) {
-	outputMarkdown(fmt.Sprintf(
+// OrganizationDetails prints details of an org with formatting,
+// including its members and teams if given
+func OrganizationDetails(org *gitea.Organization, members []*gitea.User, teams []*gitea.Team) {
+	out := fmt.Sprintf(
 		"# %s\n%s\n\n- Visibility: %s\n- Location: %s\n- Website: %s\n",
 		org.UserName,
 		org.Description,
 		org.Visibility,
 		org.Location,
 		org.Website,
-	), "")
+	)
+
+	if len(members) != 0 {
+		out += "\n## Members\n"
+		for _, m := range members {
+			out += fmt.Sprintf("- %s\n", m.UserName)
+		}
+	}
+	if len(teams) != 0 {
+		out += "\n## Teams\n"
+		for _, t := range teams {
+			out += fmt.Sprintf("- **%s** (%s)", t.Name, t.Permission)
+			if len(t.Description) != 0 {
+				out += ": " + t.Description
+			}
+			out += "\n"
+		}
+	}
+
+	outputMarkdown(out, "")
 }
 
 // OrganizationsList prints a listing of the organizations
@@ -0,0 +1,73 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package print
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// TeamDetails prints a team with its members and repos
+func TeamDetails(team *gitea.Team, members []*gitea.User, repos []*gitea.Repository) {
+	out := fmt.Sprintf("# %s\n", team.Name)
+	if len(team.Description) != 0 {
+		out += fmt.Sprintf("*%s*\n", team.Description)
+	}
+
+	out += fmt.Sprintf("\n- Permission:\t%s\n", team.Permission)
+	out += fmt.Sprintf("- Units:\t%s\n", formatTeamUnits(team.Units))
+	out += fmt.Sprintf("- Can Create Repos:\t%s\n", formatBoolean(team.CanCreateOrgRepo, true))
+	out += fmt.Sprintf("- All Repos:\t%s\n", formatBoolean(team.IncludesAllRepositories, true))
+
+	if len(members) != 0 {
+		out += "\n## Members\n"
+		for _, m := range members {
+			out += fmt.Sprintf("- %s\n", m.UserName)
+		}
+	}
+	if len(repos) != 0 {
+		out += "\n## Repos\n"
+		for _, r := range repos {
+			out += fmt.Sprintf("- %s\n", r.FullName)
+		}
+	}
+
+	outputMarkdown(out, "")
+}
+
+// TeamsList prints a listing of teams
+func TeamsList(teams []*gitea.Team, output string) {
+	t := tableWithHeader(
+		"Name",
+		"Description",
+		"Permission",
+		"Units",
+		"All Repos",
+		"Can Create Repos",
+	)
+
+	machineReadable := isMachineReadable(output)
+	for _, team := range teams {
+		t.addRow(
+			team.Name,
+			team.Description,
+			string(team.Permission),
+			formatTeamUnits(team.Units),
+			formatBoolean(team.IncludesAllRepositories, !machineReadable),
+			formatBoolean(team.CanCreateOrgRepo, !machineReadable),
+		)
+	}
+	t.print(output)
+}
+
+func formatTeamUnits(units []gitea.RepoUnitType) string {
+	names := make([]string, len(units))
+	for i, u := range units {
+		names[i] = strings.TrimPrefix(string(u), "repo.")
+	}
+	return strings.Join(names, " ")
+}
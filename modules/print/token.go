@@ -0,0 +1,34 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package print
+
+import (
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// AccessTokensList prints a listing of access tokens. The token itself is only
+// known right after creation, otherwise its last eight characters are shown.
+func AccessTokensList(tokens []*gitea.AccessToken, output string) {
+	t := tableWithHeader(
+		"ID",
+		"Name",
+		"Token",
+	)
+
+	for _, token := range tokens {
+		value := token.Token
+		if len(value) == 0 {
+			value = "…" + token.TokenLastEight
+		}
+		t.addRow(
+			fmt.Sprint(token.ID),
+			token.Name,
+			value,
+		)
+	}
+	t.print(output)
+}
@@ -0,0 +1,22 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package print
+
+import (
+	"strings"
+)
+
+// RepoTopicsList prints a listing of the topics of the given repos
+func RepoTopicsList(repos []string, topics map[string][]string, output string) {
+	t := tableWithHeader(
+		"Repo",
+		"Topics",
+	)
+
+	for _, repo := range repos {
+		t.addRow(repo, strings.Join(topics[repo], " "))
+	}
+	t.print(output)
+}
@@ -52,6 +52,41 @@ func UserDetails(user *gitea.User) {
 	), "")
 }
 
+// UserSettingsDetails prints the settings of the current user
+func UserSettingsDetails(settings *gitea.UserSettings) {
+	outputMarkdown(fmt.Sprintf(
+		"# Settings\n\n- Full Name: %s\n- Description: %s\n- Website: %s\n- Location: %s\n- Language: %s\n- Theme: %s\n- Diff View Style: %s\n- Hide Email: %s\n- Hide Activity: %s\n",
+		settings.FullName,
+		settings.Description,
+		settings.Website,
+		settings.Location,
+		settings.Language,
+		settings.Theme,
+		settings.DiffViewStyle,
+		formatBoolean(settings.HideEmail, true),
+		formatBoolean(settings.HideActivity, true),
+	), "")
+}
+
+// EmailsList prints a listing of the email addresses of a user
+func EmailsList(emails []*gitea.Email, output string) {
+	t := tableWithHeader(
+		"Email",
+		"Verified",
+		"Primary",
+	)
+
+	machineReadable := isMachineReadable(output)
+	for _, e := range emails {
+		t.addRow(
+			e.Email,
+			formatBoolean(e.Verified, !machineReadable),
+			formatBoolean(e.Primary, !machineReadable),
+		)
+	}
+	t.print(output)
+}
+
 // UserList prints a listing of the users
 func UserList(user []*gitea.User, output string, fields []string) {
 	var printables = make([]printable, len(user))
@@ -71,8 +106,11 @@ var UserFields = []string{
 	"avatar_url",
 	"language",
 	"is_admin",
+	"active",
 	"restricted",
 	"prohibit_login",
+	"created",
+	"last_login",
 	"location",
 	"website",
 	"description",
@@ -109,10 +147,16 @@ func (x printableUser) FormatField(field string, machineReadable bool) string {
 		return x.Language
 	case "is_admin":
 		return formatBoolean(x.IsAdmin, !machineReadable)
+	case "active":
+		return formatBoolean(x.IsActive, !machineReadable)
 	case "restricted":
 		return formatBoolean(x.Restricted, !machineReadable)
 	case "prohibit_login":
 		return formatBoolean(x.ProhibitLogin, !machineReadable)
+	case "created":
+		return FormatTime(x.Created)
+	case "last_login":
+		return FormatTime(x.LastLogin)
 	case "location":
 		return x.Location
 	case "website":
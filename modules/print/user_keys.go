@@ -0,0 +1,67 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package print
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// PublicKeysList prints a listing of SSH keys of a user
+func PublicKeysList(keys []*gitea.PublicKey, output string) {
+	t := tableWithHeader(
+		"ID",
+		"Title",
+		"Type",
+		"Fingerprint",
+		"Created",
+	)
+
+	for _, k := range keys {
+		t.addRow(
+			fmt.Sprint(k.ID),
+			k.Title,
+			k.KeyType,
+			k.Fingerprint,
+			FormatTime(k.Created),
+		)
+	}
+	t.print(output)
+}
+
+// GPGKeysList prints a listing of GPG keys of a user
+func GPGKeysList(keys []*gitea.GPGKey, output string) {
+	t := tableWithHeader(
+		"ID",
+		"Key ID",
+		"Emails",
+		"Can Sign",
+		"Created",
+		"Expires",
+	)
+
+	machineReadable := isMachineReadable(output)
+	for _, k := range keys {
+		emails := make([]string, len(k.Emails))
+		for i, e := range k.Emails {
+			emails[i] = e.Email
+		}
+		expires := ""
+		if !k.Expires.IsZero() {
+			expires = FormatTime(k.Expires)
+		}
+		t.addRow(
+			fmt.Sprint(k.ID),
+			k.KeyID,
+			strings.Join(emails, " "),
+			formatBoolean(k.CanSign, !machineReadable),
+			FormatTime(k.Created),
+			expires,
+		)
+	}
+	t.print(output)
+}
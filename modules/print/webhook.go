@@ -0,0 +1,129 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package print
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// WebhookDetails prints a webhook formatted to stdout
+func WebhookDetails(hook *gitea.Hook) {
+	out := fmt.Sprintf("# %d: %s\n\n", hook.ID, hook.Config["url"])
+
+	out += fmt.Sprintf("- Type:\t%s\n", hook.Type)
+	out += fmt.Sprintf("- Active:\t%s\n", formatBoolean(hook.Active, true))
+	out += fmt.Sprintf("- Events:\t%s\n", strings.Join(hook.Events, ", "))
+
+	keys := make([]string, 0, len(hook.Config))
+	for k := range hook.Config {
+		if k != "url" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		out += fmt.Sprintf("- %s:\t%s\n", k, hook.Config[k])
+	}
+
+	out += fmt.Sprintf("- Created:\t%s\n", FormatTime(hook.Created))
+	out += fmt.Sprintf("- Updated:\t%s\n", FormatTime(hook.Updated))
+
+	outputMarkdown(out, "")
+}
+
+// WebhooksList prints a listing of webhooks
+func WebhooksList(hooks []*gitea.Hook, output string) {
+	t := tableWithHeader(
+		"ID",
+		"Type",
+		"URL",
+		"Events",
+		"Content Type",
+		"Active",
+		"Updated",
+	)
+
+	machineReadable := isMachineReadable(output)
+	for _, h := range hooks {
+		t.addRow(
+			fmt.Sprint(h.ID),
+			h.Type,
+			h.Config["url"],
+			strings.Join(h.Events, " "),
+			h.Config["content_type"],
+			formatBoolean(h.Active, !machineReadable),
+			FormatTime(h.Updated),
+		)
+	}
+	t.print(output)
+}
+
+// webhookPayload contains the fields of all webhook payloads used for the summary
+type webhookPayload struct {
+	Action      string                 `json:"action"`
+	Ref         string                 `json:"ref"`
+	RefType     string                 `json:"ref_type"`
+	Commits     []*gitea.PayloadCommit `json:"commits"`
+	Pusher      *gitea.User            `json:"pusher"`
+	Sender      *gitea.User            `json:"sender"`
+	Repository  *gitea.Repository      `json:"repository"`
+	PullRequest *gitea.PullRequest     `json:"pull_request"`
+	Issue       *gitea.Issue           `json:"issue"`
+	Comment     *gitea.Comment         `json:"comment"`
+	Release     *gitea.Release         `json:"release"`
+}
+
+// WebhookEvent prints a summary of a received webhook event, and optionally its full payload
+func WebhookEvent(event, delivery string, payload []byte, showPayload bool) {
+	fmt.Printf("[%s] %s (%s)\n", time.Now().Format("15:04:05"), event, delivery)
+
+	var p webhookPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		fmt.Printf("  invalid payload: %s\n", err)
+		return
+	}
+	var sender, repo string
+	if p.Sender != nil {
+		sender = p.Sender.UserName
+	}
+	if p.Repository != nil {
+		repo = p.Repository.FullName
+	}
+
+	switch {
+	case event == "push":
+		fmt.Printf("  %s pushed %d commits to %s of %s\n", sender, len(p.Commits), p.Ref, repo)
+		for _, c := range p.Commits {
+			fmt.Printf("    %s %s (%s)\n", formatSha(c.ID), formatCommitTitle(c.Message), formatCommitAuthor(c))
+		}
+	case p.PullRequest != nil:
+		fmt.Printf("  %s %s pull request #%d of %s: %s\n", sender, p.Action, p.PullRequest.Index, repo, p.PullRequest.Title)
+	case p.Issue != nil:
+		fmt.Printf("  %s %s issue #%d of %s: %s\n", sender, p.Action, p.Issue.Index, repo, p.Issue.Title)
+	case p.Release != nil:
+		fmt.Printf("  %s %s release %s of %s\n", sender, p.Action, p.Release.TagName, repo)
+	case event == "create" || event == "delete":
+		fmt.Printf("  %s %sd %s %s in %s\n", sender, event, p.RefType, p.Ref, repo)
+	default:
+		fmt.Printf("  %s %s %s\n", sender, p.Action, repo)
+	}
+	if p.Comment != nil {
+		fmt.Printf("  comment: %s\n", formatCommitTitle(p.Comment.Body))
+	}
+
+	if showPayload {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, payload, "  ", "  "); err == nil {
+			fmt.Printf("  %s\n", indented.String())
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"code.gitea.io/sdk/gitea"
+	"gopkg.in/yaml.v2"
+)
+
+// BranchProtectionRule is the declarative form of a branch protection, as read from YAML files
+type BranchProtectionRule struct {
+	Branch                        string   `yaml:"branch"`
+	EnablePush                    bool     `yaml:"enable_push"`
+	EnablePushWhitelist           bool     `yaml:"enable_push_whitelist"`
+	PushWhitelistUsernames        []string `yaml:"push_whitelist_usernames,flow"`
+	PushWhitelistTeams            []string `yaml:"push_whitelist_teams,flow"`
+	PushWhitelistDeployKeys       bool     `yaml:"push_whitelist_deploy_keys"`
+	EnableMergeWhitelist          bool     `yaml:"enable_merge_whitelist"`
+	MergeWhitelistUsernames       []string `yaml:"merge_whitelist_usernames,flow"`
+	MergeWhitelistTeams           []string `yaml:"merge_whitelist_teams,flow"`
+	EnableStatusCheck             bool     `yaml:"enable_status_check"`
+	StatusCheckContexts           []string `yaml:"status_check_contexts,flow"`
+	RequiredApprovals             int64    `yaml:"required_approvals"`
+	EnableApprovalsWhitelist      bool     `yaml:"enable_approvals_whitelist"`
+	ApprovalsWhitelistUsernames   []string `yaml:"approvals_whitelist_usernames,flow"`
+	ApprovalsWhitelistTeams       []string `yaml:"approvals_whitelist_teams,flow"`
+	BlockOnRejectedReviews        bool     `yaml:"block_on_rejected_reviews"`
+	BlockOnOfficialReviewRequests bool     `yaml:"block_on_official_review_requests"`
+	BlockOnOutdatedBranch         bool     `yaml:"block_on_outdated_branch"`
+	DismissStaleApprovals         bool     `yaml:"dismiss_stale_approvals"`
+	RequireSignedCommits          bool     `yaml:"require_signed_commits"`
+	ProtectedFilePatterns         string   `yaml:"protected_file_patterns"`
+}
+
+// WriteBranchProtectionRules saves the given rules as YAML file
+func WriteBranchProtectionRules(rules []BranchProtectionRule, path string) error {
+	content, err := yaml.Marshal(rules)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, 0644)
+}
+
+// ReadBranchProtectionRules parses a YAML file containing a list of branch protection rules
+func ReadBranchProtectionRules(path string) ([]BranchProtectionRule, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules []BranchProtectionRule
+	if err = yaml.UnmarshalStrict(content, &rules); err != nil {
+		return nil, fmt.Errorf("could not parse '%s': %s", path, err)
+	}
+	seen := make(map[string]bool, len(rules))
+	for _, r := range rules {
+		if len(r.Branch) == 0 {
+			return nil, fmt.Errorf("rule without branch name in '%s'", path)
+		}
+		if seen[r.Branch] {
+			return nil, fmt.Errorf("duplicate rule for branch '%s' in '%s'", r.Branch, path)
+		}
+		seen[r.Branch] = true
+	}
+	return rules, nil
+}
+
+// BranchProtectionToRule converts a branch protection from the API to its declarative form
+func BranchProtectionToRule(bp *gitea.BranchProtection) BranchProtectionRule {
+	return BranchProtectionRule{
+		Branch:                        bp.BranchName,
+		EnablePush:                    bp.EnablePush,
+		EnablePushWhitelist:           bp.EnablePushWhitelist,
+		PushWhitelistUsernames:        bp.PushWhitelistUsernames,
+		PushWhitelistTeams:            bp.PushWhitelistTeams,
+		PushWhitelistDeployKeys:       bp.PushWhitelistDeployKeys,
+		EnableMergeWhitelist:          bp.EnableMergeWhitelist,
+		MergeWhitelistUsernames:       bp.MergeWhitelistUsernames,
+		MergeWhitelistTeams:           bp.MergeWhitelistTeams,
+		EnableStatusCheck:             bp.EnableStatusCheck,
+		StatusCheckContexts:           bp.StatusCheckContexts,
+		RequiredApprovals:             bp.RequiredApprovals,
+		EnableApprovalsWhitelist:      bp.EnableApprovalsWhitelist,
+		ApprovalsWhitelistUsernames:   bp.ApprovalsWhitelistUsernames,
+		ApprovalsWhitelistTeams:       bp.ApprovalsWhitelistTeams,
+		BlockOnRejectedReviews:        bp.BlockOnRejectedReviews,
+		BlockOnOfficialReviewRequests: bp.BlockOnOfficialReviewRequests,
+		BlockOnOutdatedBranch:         bp.BlockOnOutdatedBranch,
+		DismissStaleApprovals:         bp.DismissStaleApprovals,
+		RequireSignedCommits:          bp.RequireSignedCommits,
+		ProtectedFilePatterns:         bp.ProtectedFilePatterns,
+	}
+}
+
+func (r BranchProtectionRule) createOption() gitea.CreateBranchProtectionOption {
+	return gitea.CreateBranchProtectionOption{
+		BranchName:                    r.Branch,
+		EnablePush:                    r.EnablePush,
+		EnablePushWhitelist:           r.EnablePushWhitelist,
+		PushWhitelistUsernames:        r.PushWhitelistUsernames,
+		PushWhitelistTeams:            r.PushWhitelistTeams,
+		PushWhitelistDeployKeys:       r.PushWhitelistDeployKeys,
+		EnableMergeWhitelist:          r.EnableMergeWhitelist,
+		MergeWhitelistUsernames:       r.MergeWhitelistUsernames,
+		MergeWhitelistTeams:           r.MergeWhitelistTeams,
+		EnableStatusCheck:             r.EnableStatusCheck,
+		StatusCheckContexts:           r.StatusCheckContexts,
+		RequiredApprovals:             r.RequiredApprovals,
+		EnableApprovalsWhitelist:      r.EnableApprovalsWhitelist,
+		ApprovalsWhitelistUsernames:   r.ApprovalsWhitelistUsernames,
+		ApprovalsWhitelistTeams:       r.ApprovalsWhitelistTeams,
+		BlockOnRejectedReviews:        r.BlockOnRejectedReviews,
+		BlockOnOfficialReviewRequests: r.BlockOnOfficialReviewRequests,
+		BlockOnOutdatedBranch:         r.BlockOnOutdatedBranch,
+		DismissStaleApprovals:         r.DismissStaleApprovals,
+		RequireSignedCommits:          r.RequireSignedCommits,
+		ProtectedFilePatterns:         r.ProtectedFilePatterns,
+	}
+}
+
+func (r BranchProtectionRule) editOption() gitea.EditBranchProtectionOption {
+	return gitea.EditBranchProtectionOption{
+		EnablePush:                    &r.EnablePush,
+		EnablePushWhitelist:           &r.EnablePushWhitelist,
+		PushWhitelistUsernames:        nonNil(r.PushWhitelistUsernames),
+		PushWhitelistTeams:            nonNil(r.PushWhitelistTeams),
+		PushWhitelistDeployKeys:       &r.PushWhitelistDeployKeys,
+		EnableMergeWhitelist:          &r.EnableMergeWhitelist,
+		MergeWhitelistUsernames:       nonNil(r.MergeWhitelistUsernames),
+		MergeWhitelistTeams:           nonNil(r.MergeWhitelistTeams),
+		EnableStatusCheck:             &r.EnableStatusCheck,
+		StatusCheckContexts:           nonNil(r.StatusCheckContexts),
+		RequiredApprovals:             &r.RequiredApprovals,
+		EnableApprovalsWhitelist:      &r.EnableApprovalsWhitelist,
+		ApprovalsWhitelistUsernames:   nonNil(r.ApprovalsWhitelistUsernames),
+		ApprovalsWhitelistTeams:       nonNil(r.ApprovalsWhitelistTeams),
+		BlockOnRejectedReviews:        &r.BlockOnRejectedReviews,
+		BlockOnOfficialReviewRequests: &r.BlockOnOfficialReviewRequests,
+		BlockOnOutdatedBranch:         &r.BlockOnOutdatedBranch,
+		DismissStaleApprovals:         &r.DismissStaleApprovals,
+		RequireSignedCommits:          &r.RequireSignedCommits,
+		ProtectedFilePatterns:         &r.ProtectedFilePatterns,
+	}
+}
+
+// nonNil makes sure an empty list is sent as such, clearing the value on the server
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+// ApplyBranchProtections creates or updates the branch protections of a repo
+// to match the given rules, and prints the changes. If dryRun is set, changes
+// are only printed. If prune is set, protections not in rules are deleted.
+func ApplyBranchProtections(client *gitea.Client, owner, repo string, rules []BranchProtectionRule, dryRun, prune bool) error {
+	existing, _, err := client.ListBranchProtections(owner, repo, gitea.ListBranchProtectionsOptions{})
+	if err != nil {
+		return err
+	}
+	current := make(map[string]*gitea.BranchProtection, len(existing))
+	for _, bp := range existing {
+		current[bp.BranchName] = bp
+	}
+
+	fmt.Printf("%s/%s:\n", owner, repo)
+	changed := false
+
+	for _, rule := range rules {
+		bp, ok := current[rule.Branch]
+		if !ok {
+			changed = true
+			fmt.Printf("  + %s\n", rule.Branch)
+			if !dryRun {
+				if _, _, err := client.CreateBranchProtection(owner, repo, rule.createOption()); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		diff, err := DiffBranchProtectionRules(BranchProtectionToRule(bp), rule)
+		if err != nil {
+			return err
+		}
+		if len(diff) == 0 {
+			continue
+		}
+		changed = true
+		fmt.Printf("  ~ %s\n", rule.Branch)
+		for _, d := range diff {
+			fmt.Printf("      %s\n", d)
+		}
+		if !dryRun {
+			if _, _, err := client.EditBranchProtection(owner, repo, rule.Branch, rule.editOption()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if prune {
+		for _, bp := range existing {
+			if ruleIndex(rules, bp.BranchName) != -1 {
+				continue
+			}
+			changed = true
+			fmt.Printf("  - %s\n", bp.BranchName)
+			if !dryRun {
+				if _, err := client.DeleteBranchProtection(owner, repo, bp.BranchName); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if !changed {
+		fmt.Println("  no changes")
+	}
+	return nil
+}
+
+// DiffBranchProtectionRules lists the fields that differ between the two rules
+// in the format "<field>: <old> -> <new>"
+func DiffBranchProtectionRules(current, desired BranchProtectionRule) ([]string, error) {
+	oldFields, err := ruleFields(current)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := ruleFields(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff []string
+	for i, f := range newFields {
+		oldVal, newVal := fmt.Sprint(oldFields[i].Value), fmt.Sprint(f.Value)
+		if oldVal != newVal {
+			diff = append(diff, fmt.Sprintf("%s: %s -> %s", f.Key, oldVal, newVal))
+		}
+	}
+	return diff, nil
+}
+
+// ruleFields returns the fields of a rule in a generic form, by name as in YAML
+func ruleFields(rule BranchProtectionRule) (yaml.MapSlice, error) {
+	// normalize lists, so they compare equal regardless of order or missing values
+	rule.PushWhitelistUsernames = sortedList(rule.PushWhitelistUsernames)
+	rule.PushWhitelistTeams = sortedList(rule.PushWhitelistTeams)
+	rule.MergeWhitelistUsernames = sortedList(rule.MergeWhitelistUsernames)
+	rule.MergeWhitelistTeams = sortedList(rule.MergeWhitelistTeams)
+	rule.StatusCheckContexts = sortedList(rule.StatusCheckContexts)
+	rule.ApprovalsWhitelistUsernames = sortedList(rule.ApprovalsWhitelistUsernames)
+	rule.ApprovalsWhitelistTeams = sortedList(rule.ApprovalsWhitelistTeams)
+
+	raw, err := yaml.Marshal(rule)
+	if err != nil {
+		return nil, err
+	}
+	var fields yaml.MapSlice
+	err = yaml.Unmarshal(raw, &fields)
+	return fields, err
+}
+
+func sortedList(list []string) []string {
+	sorted := append([]string{}, list...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func ruleIndex(rules []BranchProtectionRule, branch string) int {
+	for i, r := range rules {
+		if r.Branch == branch {
+			return i
+		}
+	}
+	return -1
+}
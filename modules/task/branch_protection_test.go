@@ -0,0 +1,36 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffBranchProtectionRules(t *testing.T) {
+	current := BranchProtectionRule{
+		Branch:              "main",
+		EnablePush:          true,
+		StatusCheckContexts: []string{"ci/b", "ci/a"},
+	}
+
+	// list order and nil vs. empty lists must not result in a change
+	desired := current
+	desired.StatusCheckContexts = []string{"ci/a", "ci/b"}
+	desired.PushWhitelistTeams = []string{}
+	diff, err := DiffBranchProtectionRules(current, desired)
+	assert.NoError(t, err)
+	assert.Empty(t, diff)
+
+	desired.RequiredApprovals = 2
+	desired.DismissStaleApprovals = true
+	diff, err = DiffBranchProtectionRules(current, desired)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"required_approvals: 0 -> 2",
+		"dismiss_stale_approvals: false -> true",
+	}, diff)
+}
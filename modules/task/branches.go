@@ -0,0 +1,94 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	local_git "code.gitea.io/tea/modules/git"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// MergedBranches returns the branches whose head commit is part of the history
+// of the default branch. As the API provides no compare endpoint, only the last
+// depth commits of the default branch are searched, so branches merged before
+// are not found. complete is false, if the search stopped at depth before all
+// branches were found.
+func MergedBranches(client *gitea.Client, owner, repo, defaultBranch string, branches []*gitea.Branch, depth int) (merged []*gitea.Branch, complete bool, err error) {
+	wanted := make(map[string]bool, len(branches))
+	for _, b := range branches {
+		if b.Name != defaultBranch && b.Commit != nil {
+			wanted[b.Commit.ID] = true
+		}
+	}
+
+	found, complete, err := findCommits(func(page int) ([]string, error) {
+		commits, _, err := client.ListRepoCommits(owner, repo, gitea.ListCommitOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: 50},
+			SHA:         defaultBranch,
+		})
+		shas := make([]string, len(commits))
+		for i, c := range commits {
+			shas[i] = c.SHA
+		}
+		return shas, err
+	}, wanted, depth)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, b := range branches {
+		if b.Name != defaultBranch && b.Commit != nil && found[b.Commit.ID] {
+			merged = append(merged, b)
+		}
+	}
+	return merged, complete, nil
+}
+
+// BranchDivergence counts the commits each branch is ahead & behind of the given
+// default branch commit, using the local clone r. Branches whose commits are not
+// available locally are left out of the counts, and reported in missing.
+func BranchDivergence(r *local_git.TeaRepo, defaultSha string, branches []*gitea.Branch) (ahead, behind map[string]int, missing int) {
+	ahead = make(map[string]int, len(branches))
+	behind = make(map[string]int, len(branches))
+	for _, b := range branches {
+		if b.Commit == nil {
+			continue
+		}
+		a, bh, err := r.TeaCountDivergence(defaultSha, b.Commit.ID)
+		if err != nil {
+			missing++
+			continue
+		}
+		ahead[b.Name], behind[b.Name] = a, bh
+	}
+	return ahead, behind, missing
+}
+
+// findCommits pages through a commit history via listPage, until all wanted
+// commits were found, depth commits were searched, or the history ends.
+// It returns the wanted commits that were found, and whether the search
+// completed without hitting depth.
+func findCommits(listPage func(page int) ([]string, error), wanted map[string]bool, depth int) (found map[string]bool, complete bool, err error) {
+	found = make(map[string]bool, len(wanted))
+	for page, searched := 1, 0; len(found) < len(wanted); page++ {
+		if searched >= depth {
+			return found, false, nil
+		}
+		shas, err := listPage(page)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(shas) == 0 {
+			break
+		}
+		for _, sha := range shas {
+			if wanted[sha] {
+				found[sha] = true
+			}
+		}
+		searched += len(shas)
+	}
+	return found, true, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCommits(t *testing.T) {
+	history := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+	var requested []int
+	listPage := func(page int) ([]string, error) {
+		requested = append(requested, page)
+		if page > len(history) {
+			return nil, nil
+		}
+		return history[page-1], nil
+	}
+
+	// stops as soon as all commits are found
+	found, complete, err := findCommits(listPage, map[string]bool{"b": true, "c": true}, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"b": true, "c": true}, found)
+	assert.True(t, complete)
+	assert.Equal(t, []int{1, 2}, requested)
+
+	// stops at the end of the history
+	requested = nil
+	found, complete, err = findCommits(listPage, map[string]bool{"a": true, "x": true}, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"a": true}, found)
+	assert.True(t, complete)
+	assert.Equal(t, []int{1, 2, 3, 4}, requested)
+
+	// stops after depth commits were searched
+	requested = nil
+	found, complete, err = findCommits(listPage, map[string]bool{"e": true}, 3)
+	assert.NoError(t, err)
+	assert.Empty(t, found)
+	assert.False(t, complete)
+	assert.Equal(t, []int{1, 2}, requested)
+
+	// nothing to find
+	requested = nil
+	found, complete, err = findCommits(listPage, map[string]bool{}, 100)
+	assert.NoError(t, err)
+	assert.Empty(t, found)
+	assert.True(t, complete)
+	assert.Empty(t, requested)
+}
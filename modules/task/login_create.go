@@ -5,8 +5,13 @@
 package task
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"code.gitea.io/tea/modules/config"
@@ -15,36 +20,40 @@ import (
 	"code.gitea.io/sdk/gitea"
 )
 
-// CreateLogin create a login to be stored in config
-func CreateLogin(name, token, user, passwd, sshKey, giteaURL string, insecure bool) error {
+// ErrOTPRequired is returned by CreateLogin, if authentication with username &
+// password failed because two-factor authentication is enabled and no OTP was given
+var ErrOTPRequired = errors.New("authentication failed, a one-time password is required as two-factor authentication is enabled")
+
+// CreateLogin create a login to be stored in config, and returns it
+func CreateLogin(name, token, user, passwd, otp, sshKey, giteaURL string, insecure bool) (*config.Login, error) {
 	// checks ...
 	// ... if we have a url
 	if len(giteaURL) == 0 {
-		return fmt.Errorf("You have to input Gitea server URL")
+		return nil, fmt.Errorf("You have to input Gitea server URL")
 	}
 
 	// ... if there already exist a login with same name
 	if login := config.GetLoginByName(name); login != nil {
-		return fmt.Errorf("login name '%s' has already been used", login.Name)
+		return nil, fmt.Errorf("login name '%s' has already been used", login.Name)
 	}
 	// ... if we already use this token
 	if login := config.GetLoginByToken(token); login != nil {
-		return fmt.Errorf("token already been used, delete login '%s' first", login.Name)
+		return nil, fmt.Errorf("token already been used, delete login '%s' first", login.Name)
 	}
 
 	// .. if we have enough information to authenticate
 	if len(token) == 0 && (len(user)+len(passwd)) == 0 {
-		return fmt.Errorf("No token set")
+		return nil, fmt.Errorf("No token set")
 	} else if len(user) != 0 && len(passwd) == 0 {
-		return fmt.Errorf("No password set")
+		return nil, fmt.Errorf("No password set")
 	} else if len(user) == 0 && len(passwd) != 0 {
-		return fmt.Errorf("No user set")
+		return nil, fmt.Errorf("No user set")
 	}
 
 	// Normalize URL
 	serverURL, err := utils.NormalizeURL(giteaURL)
 	if err != nil {
-		return fmt.Errorf("Unable to parse URL: %s", err)
+		return nil, fmt.Errorf("Unable to parse URL: %s", err)
 	}
 
 	login := config.Login{
@@ -57,9 +66,11 @@ func CreateLogin(name, token, user, passwd, sshKey, giteaURL string, insecure bo
 	}
 
 	if len(token) == 0 {
-		if login.Token, err = generateToken(login, user, passwd); err != nil {
-			return err
+		t, err := generateToken(login, user, passwd, otp)
+		if err != nil {
+			return nil, err
 		}
+		login.Token, login.TokenID = t.Token, t.ID
 	}
 
 	client := login.Client()
@@ -67,13 +78,13 @@ func CreateLogin(name, token, user, passwd, sshKey, giteaURL string, insecure bo
 	// Verify if authentication works and get user info
 	u, _, err := client.GetMyUserInfo()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	login.User = u.UserName
 
 	if len(login.Name) == 0 {
 		if login.Name, err = GenerateLoginName(giteaURL, login.User); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -89,21 +100,25 @@ func CreateLogin(name, token, user, passwd, sshKey, giteaURL string, insecure bo
 	}
 
 	if err = config.AddLogin(&login); err != nil {
-		return err
+		return nil, err
 	}
 
 	fmt.Printf("Login as %s on %s successful. Added this login as %s\n", login.User, login.URL, login.Name)
 
-	return nil
+	return &login, nil
 }
 
 // generateToken creates a new token when given BasicAuth credentials
-func generateToken(login config.Login, user, pass string) (string, error) {
+func generateToken(login config.Login, user, pass, otp string) (*gitea.AccessToken, error) {
+	login.OTP = otp
 	client := login.Client(gitea.SetBasicAuth(user, pass))
 
-	tl, _, err := client.ListAccessTokens(gitea.ListAccessTokensOptions{})
+	tl, resp, err := client.ListAccessTokens(gitea.ListAccessTokensOptions{})
 	if err != nil {
-		return "", err
+		if resp != nil && resp.StatusCode == 401 && len(otp) == 0 && isOTPRequired(&login, user, pass) {
+			return nil, ErrOTPRequired
+		}
+		return nil, err
 	}
 	host, _ := os.Hostname()
 	tokenName := host + "-tea"
@@ -117,7 +132,45 @@ func generateToken(login config.Login, user, pass string) (string, error) {
 	}
 
 	t, _, err := client.CreateAccessToken(gitea.CreateAccessTokenOption{Name: tokenName})
-	return t.Token, err
+	return t, err
+}
+
+// isOTPRequired checks whether authentication with username & password fails
+// because of two-factor authentication, as the SDK doesn't expose the reason.
+func isOTPRequired(login *config.Login, user, pass string) bool {
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(login.URL, "/")+"/api/v1/user", nil)
+	if err != nil {
+		return false
+	}
+	req.SetBasicAuth(user, pass)
+	resp, err := login.HTTPClient().Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	return err == nil && isOTPErrorBody(body)
+}
+
+// isOTPErrorBody checks the body of a 401 response for the reason of the failure.
+// The OTP check replies with the plain text "Unauthorized", or mentions the OTP
+// in newer versions of Gitea. Any other reply, e.g. the JSON message of failed
+// authentication or an error page of a proxy, is no OTP request.
+func isOTPErrorBody(body []byte) bool {
+	if strings.TrimSpace(string(body)) == "Unauthorized" {
+		return true
+	}
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &apiErr); err != nil {
+		return false
+	}
+	msg := strings.ToLower(apiErr.Message)
+	return strings.Contains(msg, "otp") || strings.Contains(msg, "two-factor") || strings.Contains(msg, "2fa")
 }
 
 // GenerateLoginName generates a name string based on instance URL & adds username if the result is not unique
@@ -0,0 +1,26 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsOTPErrorBody(t *testing.T) {
+	// written by the OTP check of Gitea
+	assert.True(t, isOTPErrorBody([]byte("Unauthorized\n")))
+	assert.True(t, isOTPErrorBody([]byte(`{"message":"invalid provided OTP"}`)))
+
+	// wrong credentials
+	assert.False(t, isOTPErrorBody([]byte(`{"message":"basic auth required","url":"https://gitea.com/api/swagger"}`)))
+	assert.False(t, isOTPErrorBody([]byte(`{"message":"token is required"}`)))
+
+	// other errors, e.g. of a reverse proxy
+	assert.False(t, isOTPErrorBody([]byte("<html><body><h1>401 Unauthorized</h1></body></html>")))
+	assert.False(t, isOTPErrorBody([]byte("Unauthorized: invalid session")))
+	assert.False(t, isOTPErrorBody(nil))
+}
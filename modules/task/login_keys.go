@@ -0,0 +1,141 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// LocalGPGKey is a secret key of the local GPG keyring
+type LocalGPGKey struct {
+	ID      string
+	UserIDs []string
+}
+
+// LocalSSHKeys lists the public keys in ~/.ssh, for which a private key exists
+func LocalSSHKeys() ([]string, error) {
+	glob, err := utils.AbsPathWithExpansion("~/.ssh/*.pub")
+	if err != nil {
+		return nil, err
+	}
+	pubkeyPaths, err := filepath.Glob(glob)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, p := range pubkeyPaths {
+		if exists, err := utils.FileExist(strings.TrimSuffix(p, ".pub")); err == nil && exists {
+			keys = append(keys, p)
+		}
+	}
+	return keys, nil
+}
+
+// UploadSSHKey adds the public key at pubkeyPath to the account of the login,
+// and configures the login to use the corresponding private key
+func UploadSSHKey(login *config.Login, pubkeyPath string) error {
+	_, content, err := ReadSSHPublicKey(pubkeyPath)
+	if err != nil {
+		return err
+	}
+	if _, _, err = login.Client().CreatePublicKey(gitea.CreateKeyOption{
+		Title: sshKeyTitle(),
+		Key:   content,
+	}); err != nil {
+		return err
+	}
+
+	login.SSHKey = strings.TrimSuffix(pubkeyPath, ".pub")
+	return config.UpdateLogin(login)
+}
+
+// GenerateAndUploadSSHKey creates a new ed25519 keypair at path, adds it to the
+// account of the login, and configures the login to use it
+func GenerateAndUploadSSHKey(login *config.Login, path string) error {
+	path, err := utils.AbsPathWithExpansion(path)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	if _, _, err = GenerateSSHKey(path, sshKeyTitle()); err != nil {
+		return err
+	}
+	fmt.Printf("Stored private key at %s\n", path)
+	return UploadSSHKey(login, path+".pub")
+}
+
+func sshKeyTitle() string {
+	host, _ := os.Hostname()
+	return host + "-tea"
+}
+
+// HasGPGKey checks whether the account of the login has a GPG key registered
+func HasGPGKey(login *config.Login) (bool, error) {
+	keys, _, err := login.Client().ListMyGPGKeys(&gitea.ListGPGKeysOptions{})
+	return len(keys) != 0, err
+}
+
+// LocalGPGKeys lists the secret keys of the local GPG keyring.
+// If gpg is not installed, no keys are returned.
+func LocalGPGKeys() ([]LocalGPGKey, error) {
+	if _, err := exec.LookPath("gpg"); err != nil {
+		return nil, nil
+	}
+	out, err := exec.Command("gpg", "--list-secret-keys", "--with-colons").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseGPGSecretKeys(string(out)), nil
+}
+
+// parseGPGSecretKeys reads the output of 'gpg --list-secret-keys --with-colons'
+func parseGPGSecretKeys(output string) []LocalGPGKey {
+	var keys []LocalGPGKey
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, ":")
+		switch {
+		case len(fields) > 4 && fields[0] == "sec":
+			// skip revoked and expired keys
+			if fields[1] == "r" || fields[1] == "e" {
+				keys = append(keys, LocalGPGKey{})
+				continue
+			}
+			keys = append(keys, LocalGPGKey{ID: fields[4]})
+		case len(fields) > 9 && fields[0] == "uid" && len(keys) != 0:
+			keys[len(keys)-1].UserIDs = append(keys[len(keys)-1].UserIDs, fields[9])
+		}
+	}
+
+	valid := keys[:0]
+	for _, k := range keys {
+		if len(k.ID) != 0 {
+			valid = append(valid, k)
+		}
+	}
+	return valid
+}
+
+// UploadGPGKey exports the public part of a key from the local GPG keyring,
+// and adds it to the account of the login
+func UploadGPGKey(login *config.Login, id string) error {
+	armored, err := exec.Command("gpg", "--armor", "--export", id).Output()
+	if err != nil {
+		return err
+	}
+	_, _, err = login.Client().CreateGPGKey(gitea.CreateGPGKeyOption{ArmoredKey: string(armored)})
+	return err
+}
@@ -0,0 +1,31 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGPGSecretKeys(t *testing.T) {
+	output := `sec:u:255:22:1A2B3C4D5E6F7A8B:1609459200:::u:::scESC:::+::ed25519:::0:
+fpr:::::::::0123456789ABCDEF01231A2B3C4D5E6F7A8B:
+grp:::::::::AAAA:
+uid:u::::1609459200::HASH::Alice <alice@example.com>::::::::::0:
+uid:u::::1609459200::HASH::Alice <alice@work.example.com>::::::::::0:
+ssb:u:255:18:9999999999999999:1609459200::::::e:::+::cv25519::
+sec:r:4096:1:DEADBEEFDEADBEEF:1500000000:::u:::sc:::+:::23::0:
+uid:r::::1500000000::HASH::Old Alice <alice@old.example.com>::::::::::0:
+sec:u:4096:1:0011223344556677:1609459200:1700000000::u:::scESC:::+:::23::0:
+uid:u::::1609459200::HASH::Bob <bob@example.com>::::::::::0:
+`
+	assert.Equal(t, []LocalGPGKey{
+		{ID: "1A2B3C4D5E6F7A8B", UserIDs: []string{"Alice <alice@example.com>", "Alice <alice@work.example.com>"}},
+		{ID: "0011223344556677", UserIDs: []string{"Bob <bob@example.com>"}},
+	}, parseGPGSecretKeys(output))
+
+	assert.Empty(t, parseGPGSecretKeys(""))
+}
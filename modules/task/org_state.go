@@ -0,0 +1,674 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+	"gopkg.in/yaml.v2"
+)
+
+// OrgState is the declarative description of an organization.
+// Lists which are left out are not managed, while an empty list
+// removes all entries from the server.
+type OrgState struct {
+	Name     string       `yaml:"name"`
+	Settings *OrgSettings `yaml:"settings,omitempty"`
+	Teams    []TeamState  `yaml:"teams"`
+	Repos    []RepoState  `yaml:"repos"`
+}
+
+// OrgSettings are the settings of an organization
+type OrgSettings struct {
+	FullName    string `yaml:"full_name"`
+	Description string `yaml:"description"`
+	Website     string `yaml:"website"`
+	Location    string `yaml:"location"`
+	Visibility  string `yaml:"visibility"`
+}
+
+// TeamState describes a team of an organization
+type TeamState struct {
+	Name                    string   `yaml:"name"`
+	Description             string   `yaml:"description"`
+	Permission              string   `yaml:"permission"`
+	Units                   []string `yaml:"units,flow"`
+	CanCreateOrgRepo        bool     `yaml:"can_create_org_repo"`
+	IncludesAllRepositories bool     `yaml:"includes_all_repositories"`
+	Members                 []string `yaml:"members"`
+	Repos                   []string `yaml:"repos"`
+}
+
+// RepoState describes the collaborators and labels of a repo of an organization
+type RepoState struct {
+	Name          string              `yaml:"name"`
+	Collaborators []CollaboratorState `yaml:"collaborators"`
+	Labels        []LabelState        `yaml:"labels"`
+}
+
+// CollaboratorState describes a collaborator of a repo. The permission is
+// only used when adding the collaborator, as it can't be read from the API.
+type CollaboratorState struct {
+	Name       string `yaml:"name"`
+	Permission string `yaml:"permission,omitempty"`
+}
+
+// LabelState describes a label of a repo
+type LabelState struct {
+	Name        string `yaml:"name"`
+	Color       string `yaml:"color"`
+	Description string `yaml:"description"`
+}
+
+// OrgChange is a single step of an OrgPlan
+type OrgChange struct {
+	// Action is one of "+", "~" or "-"
+	Action  string
+	Target  string
+	Details []string
+	apply   func() error
+}
+
+// OrgPlan lists the changes needed to bring an organization to the desired state
+type OrgPlan struct {
+	Org     string
+	Changes []*OrgChange
+}
+
+// ReadOrgState parses a YAML file describing an organization
+func ReadOrgState(path string) (*OrgState, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var state OrgState
+	if err = yaml.UnmarshalStrict(content, &state); err != nil {
+		return nil, fmt.Errorf("could not parse '%s': %s", path, err)
+	}
+	if len(state.Name) == 0 {
+		return nil, fmt.Errorf("no organization name given in '%s'", path)
+	}
+	return &state, nil
+}
+
+// WriteOrgState saves the state of an organization as YAML
+func WriteOrgState(state *OrgState, path string) error {
+	content, err := yaml.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if path == "-" {
+		fmt.Print(string(content))
+		return nil
+	}
+	return ioutil.WriteFile(path, content, 0644)
+}
+
+// ExportOrgState reads the current state of an organization from the server
+func ExportOrgState(client *gitea.Client, org string) (*OrgState, error) {
+	o, _, err := client.GetOrg(org)
+	if err != nil {
+		return nil, err
+	}
+	state := &OrgState{
+		Name: o.UserName,
+		Settings: &OrgSettings{
+			FullName:    o.FullName,
+			Description: o.Description,
+			Website:     o.Website,
+			Location:    o.Location,
+			Visibility:  o.Visibility,
+		},
+		Teams: []TeamState{},
+		Repos: []RepoState{},
+	}
+
+	teams, _, err := ListAllOrgTeams(client, org)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range teams {
+		members, err := listTeamMemberNames(client, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		repos := []string{}
+		if !t.IncludesAllRepositories {
+			if repos, err = listTeamRepoNames(client, t.ID); err != nil {
+				return nil, err
+			}
+		}
+		state.Teams = append(state.Teams, TeamState{
+			Name:                    t.Name,
+			Description:             t.Description,
+			Permission:              string(t.Permission),
+			Units:                   teamUnitNames(t.Units),
+			CanCreateOrgRepo:        t.CanCreateOrgRepo,
+			IncludesAllRepositories: t.IncludesAllRepositories,
+			Members:                 members,
+			Repos:                   repos,
+		})
+	}
+
+	var repos []*gitea.Repository
+	err = fetchAllPages(func(opts gitea.ListOptions) (int, error) {
+		batch, _, err := client.ListOrgRepos(org, gitea.ListOrgReposOptions{ListOptions: opts})
+		repos = append(repos, batch...)
+		return len(batch), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range repos {
+		collaborators, err := listCollaboratorNames(client, org, r.Name)
+		if err != nil {
+			return nil, err
+		}
+		labels, err := listRepoLabels(client, org, r.Name)
+		if err != nil {
+			return nil, err
+		}
+		repo := RepoState{
+			Name:          r.Name,
+			Collaborators: []CollaboratorState{},
+			Labels:        []LabelState{},
+		}
+		for _, c := range collaborators {
+			repo.Collaborators = append(repo.Collaborators, CollaboratorState{Name: c})
+		}
+		for _, l := range labels {
+			repo.Labels = append(repo.Labels, LabelState{
+				Name:        l.Name,
+				Color:       normalizeColor(l.Color),
+				Description: l.Description,
+			})
+		}
+		state.Repos = append(state.Repos, repo)
+	}
+
+	return state, nil
+}
+
+// PlanOrgState compares the desired state of an organization with the server,
+// and returns the changes needed to reach it. The organization and its repos
+// have to exist already.
+func PlanOrgState(client *gitea.Client, state *OrgState) (*OrgPlan, error) {
+	org := state.Name
+	plan := &OrgPlan{Org: org}
+
+	current, _, err := client.GetOrg(org)
+	if err != nil {
+		return nil, fmt.Errorf("could not get organization '%s': %s", org, err)
+	}
+	if state.Settings != nil {
+		planOrgSettings(plan, client, current, *state.Settings)
+	}
+
+	if state.Teams != nil {
+		if err = planTeams(plan, client, org, state.Teams); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, repo := range state.Repos {
+		if err = planRepo(plan, client, org, repo); err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+// Print shows the plan in a terraform like manner
+func (p *OrgPlan) Print() {
+	fmt.Printf("Organization %s:\n", p.Org)
+	if len(p.Changes) == 0 {
+		fmt.Println("  no changes")
+		return
+	}
+
+	counts := map[string]int{}
+	for _, c := range p.Changes {
+		counts[c.Action]++
+		fmt.Printf("  %s %s\n", c.Action, c.Target)
+		for _, d := range c.Details {
+			fmt.Printf("      %s\n", d)
+		}
+	}
+	fmt.Printf("\nPlan: %d to add, %d to change, %d to destroy.\n", counts["+"], counts["~"], counts["-"])
+}
+
+// Apply executes the changes of the plan in order, stopping at the first error
+func (p *OrgPlan) Apply() error {
+	for _, c := range p.Changes {
+		if err := c.apply(); err != nil {
+			return fmt.Errorf("could not apply '%s %s': %s", c.Action, c.Target, err)
+		}
+		fmt.Printf("%s %s\n", c.Action, c.Target)
+	}
+	return nil
+}
+
+func (p *OrgPlan) add(action, target string, details []string, apply func() error) {
+	p.Changes = append(p.Changes, &OrgChange{
+		Action:  action,
+		Target:  target,
+		Details: details,
+		apply:   apply,
+	})
+}
+
+func planOrgSettings(plan *OrgPlan, client *gitea.Client, current *gitea.Organization, desired OrgSettings) {
+	var diff []string
+	diff = appendDiff(diff, "full_name", current.FullName, desired.FullName)
+	diff = appendDiff(diff, "description", current.Description, desired.Description)
+	diff = appendDiff(diff, "website", current.Website, desired.Website)
+	diff = appendDiff(diff, "location", current.Location, desired.Location)
+	diff = appendDiff(diff, "visibility", current.Visibility, desired.Visibility)
+	if len(diff) == 0 {
+		return
+	}
+
+	plan.add("~", "settings", diff, func() error {
+		_, err := client.EditOrg(plan.Org, gitea.EditOrgOption{
+			FullName:    desired.FullName,
+			Description: desired.Description,
+			Website:     desired.Website,
+			Location:    desired.Location,
+			Visibility:  gitea.VisibleType(desired.Visibility),
+		})
+		return err
+	})
+}
+
+func planTeams(plan *OrgPlan, client *gitea.Client, org string, desired []TeamState) error {
+	existing, _, err := ListAllOrgTeams(client, org)
+	if err != nil {
+		return err
+	}
+
+	for _, team := range desired {
+		team := team
+		current := findTeam(existing, team.Name)
+		if current == nil {
+			planNewTeam(plan, client, org, team)
+			continue
+		}
+
+		var diff []string
+		diff = appendDiff(diff, "description", current.Description, team.Description)
+		diff = appendDiff(diff, "permission", string(current.Permission), team.Permission)
+		diff = appendDiff(diff, "units", strings.Join(sortedList(teamUnitNames(current.Units)), " "), strings.Join(sortedList(team.Units), " "))
+		diff = appendDiff(diff, "can_create_org_repo", current.CanCreateOrgRepo, team.CanCreateOrgRepo)
+		diff = appendDiff(diff, "includes_all_repositories", current.IncludesAllRepositories, team.IncludesAllRepositories)
+		if len(diff) != 0 {
+			id := current.ID
+			plan.add("~", fmt.Sprintf("team %s", team.Name), diff, func() error {
+				_, err := client.EditTeam(id, gitea.EditTeamOption{
+					Name:                    team.Name,
+					Description:             &team.Description,
+					Permission:              gitea.AccessMode(team.Permission),
+					CanCreateOrgRepo:        &team.CanCreateOrgRepo,
+					IncludesAllRepositories: &team.IncludesAllRepositories,
+					Units:                   teamUnitTypes(team.Units),
+				})
+				return err
+			})
+		}
+
+		if err = planTeamMembers(plan, client, current, team); err != nil {
+			return err
+		}
+		if err = planTeamRepos(plan, client, org, current, team); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range existing {
+		// the owners team can't be deleted
+		if t.Permission == gitea.AccessModeOwner || findTeamState(desired, t.Name) != nil {
+			continue
+		}
+		id := t.ID
+		plan.add("-", fmt.Sprintf("team %s", t.Name), nil, func() error {
+			_, err := client.DeleteTeam(id)
+			return err
+		})
+	}
+	return nil
+}
+
+func planNewTeam(plan *OrgPlan, client *gitea.Client, org string, team TeamState) {
+	details := []string{
+		fmt.Sprintf("permission: %s", team.Permission),
+		fmt.Sprintf("units: %s", strings.Join(team.Units, " ")),
+	}
+	if len(team.Members) != 0 {
+		details = append(details, fmt.Sprintf("members: %s", strings.Join(team.Members, ", ")))
+	}
+	if len(team.Repos) != 0 && !team.IncludesAllRepositories {
+		details = append(details, fmt.Sprintf("repos: %s", strings.Join(team.Repos, ", ")))
+	}
+
+	plan.add("+", fmt.Sprintf("team %s", team.Name), details, func() error {
+		created, _, err := client.CreateTeam(org, gitea.CreateTeamOption{
+			Name:                    team.Name,
+			Description:             team.Description,
+			Permission:              gitea.AccessMode(team.Permission),
+			CanCreateOrgRepo:        team.CanCreateOrgRepo,
+			IncludesAllRepositories: team.IncludesAllRepositories,
+			Units:                   teamUnitTypes(team.Units),
+		})
+		if err != nil {
+			return err
+		}
+		for _, m := range team.Members {
+			if _, err = client.AddTeamMember(created.ID, m); err != nil {
+				return err
+			}
+		}
+		if team.IncludesAllRepositories {
+			return nil
+		}
+		for _, r := range team.Repos {
+			if _, err = client.AddTeamRepository(created.ID, org, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func planTeamMembers(plan *OrgPlan, client *gitea.Client, current *gitea.Team, team TeamState) error {
+	if team.Members == nil {
+		return nil
+	}
+	members, err := listTeamMemberNames(client, current.ID)
+	if err != nil {
+		return err
+	}
+
+	added, removed := diffNames(members, team.Members)
+	for _, m := range added {
+		m := m
+		plan.add("+", fmt.Sprintf("member %s of team %s", m, team.Name), nil, func() error {
+			_, err := client.AddTeamMember(current.ID, m)
+			return err
+		})
+	}
+	for _, m := range removed {
+		m := m
+		plan.add("-", fmt.Sprintf("member %s of team %s", m, team.Name), nil, func() error {
+			_, err := client.RemoveTeamMember(current.ID, m)
+			return err
+		})
+	}
+	return nil
+}
+
+func planTeamRepos(plan *OrgPlan, client *gitea.Client, org string, current *gitea.Team, team TeamState) error {
+	// repos of teams with access to all repos are managed by the server
+	if team.Repos == nil || team.IncludesAllRepositories {
+		return nil
+	}
+	var repos []string
+	if !current.IncludesAllRepositories {
+		var err error
+		if repos, err = listTeamRepoNames(client, current.ID); err != nil {
+			return err
+		}
+	}
+
+	added, removed := diffNames(repos, team.Repos)
+	for _, r := range added {
+		r := r
+		plan.add("+", fmt.Sprintf("repo %s of team %s", r, team.Name), nil, func() error {
+			_, err := client.AddTeamRepository(current.ID, org, r)
+			return err
+		})
+	}
+	for _, r := range removed {
+		r := r
+		plan.add("-", fmt.Sprintf("repo %s of team %s", r, team.Name), nil, func() error {
+			_, err := client.RemoveTeamRepository(current.ID, org, r)
+			return err
+		})
+	}
+	return nil
+}
+
+func planRepo(plan *OrgPlan, client *gitea.Client, org string, repo RepoState) error {
+	if repo.Collaborators != nil {
+		collaborators, err := listCollaboratorNames(client, org, repo.Name)
+		if err != nil {
+			return fmt.Errorf("could not get collaborators of %s/%s: %s", org, repo.Name, err)
+		}
+		desired := make([]string, len(repo.Collaborators))
+		for i, c := range repo.Collaborators {
+			desired[i] = c.Name
+		}
+
+		added, removed := diffNames(collaborators, desired)
+		for _, name := range added {
+			c := repo.Collaborators[nameIndex(desired, name)]
+			permission := gitea.AccessModeWrite
+			if len(c.Permission) != 0 {
+				permission = gitea.AccessMode(c.Permission)
+			}
+			plan.add("+", fmt.Sprintf("collaborator %s of repo %s", c.Name, repo.Name), []string{
+				fmt.Sprintf("permission: %s", permission),
+			}, func() error {
+				_, err := client.AddCollaborator(org, repo.Name, c.Name, gitea.AddCollaboratorOption{Permission: &permission})
+				return err
+			})
+		}
+		for _, name := range removed {
+			name := name
+			plan.add("-", fmt.Sprintf("collaborator %s of repo %s", name, repo.Name), nil, func() error {
+				_, err := client.DeleteCollaborator(org, repo.Name, name)
+				return err
+			})
+		}
+	}
+
+	if repo.Labels != nil {
+		labels, err := listRepoLabels(client, org, repo.Name)
+		if err != nil {
+			return fmt.Errorf("could not get labels of %s/%s: %s", org, repo.Name, err)
+		}
+		planLabels(plan, client, org, repo, labels)
+	}
+	return nil
+}
+
+func planLabels(plan *OrgPlan, client *gitea.Client, org string, repo RepoState, existing []*gitea.Label) {
+	current := make(map[string]*gitea.Label, len(existing))
+	for _, l := range existing {
+		current[strings.ToLower(l.Name)] = l
+	}
+
+	for _, label := range repo.Labels {
+		label := label
+		label.Color = normalizeColor(label.Color)
+		// labels are matched case-insensitively like all other names, so a
+		// change in case renames the label instead of recreating it
+		l, ok := current[strings.ToLower(label.Name)]
+		if !ok {
+			plan.add("+", fmt.Sprintf("label %s of repo %s", label.Name, repo.Name), []string{
+				fmt.Sprintf("color: %s", label.Color),
+			}, func() error {
+				_, _, err := client.CreateLabel(org, repo.Name, gitea.CreateLabelOption{
+					Name:        label.Name,
+					Color:       "#" + label.Color,
+					Description: label.Description,
+				})
+				return err
+			})
+			continue
+		}
+
+		var diff []string
+		diff = appendDiff(diff, "name", l.Name, label.Name)
+		diff = appendDiff(diff, "color", normalizeColor(l.Color), label.Color)
+		diff = appendDiff(diff, "description", l.Description, label.Description)
+		if len(diff) == 0 {
+			continue
+		}
+		id := l.ID
+		plan.add("~", fmt.Sprintf("label %s of repo %s", label.Name, repo.Name), diff, func() error {
+			color := "#" + label.Color
+			_, _, err := client.EditLabel(org, repo.Name, id, gitea.EditLabelOption{
+				Name:        &label.Name,
+				Color:       &color,
+				Description: &label.Description,
+			})
+			return err
+		})
+	}
+
+	for _, l := range existing {
+		if findLabelState(repo.Labels, l.Name) {
+			continue
+		}
+		id := l.ID
+		plan.add("-", fmt.Sprintf("label %s of repo %s", l.Name, repo.Name), nil, func() error {
+			_, err := client.DeleteLabel(org, repo.Name, id)
+			return err
+		})
+	}
+}
+
+// fetchAllPages calls fetch with increasing page numbers, until it returns an empty page
+func fetchAllPages(fetch func(opts gitea.ListOptions) (int, error)) error {
+	for page := 1; ; page++ {
+		n, err := fetch(gitea.ListOptions{Page: page, PageSize: 50})
+		if err != nil || n == 0 {
+			return err
+		}
+	}
+}
+
+func listTeamMemberNames(client *gitea.Client, id int64) ([]string, error) {
+	names := []string{}
+	err := fetchAllPages(func(opts gitea.ListOptions) (int, error) {
+		users, _, err := client.ListTeamMembers(id, gitea.ListTeamMembersOptions{ListOptions: opts})
+		for _, u := range users {
+			names = append(names, u.UserName)
+		}
+		return len(users), err
+	})
+	return names, err
+}
+
+func listTeamRepoNames(client *gitea.Client, id int64) ([]string, error) {
+	names := []string{}
+	err := fetchAllPages(func(opts gitea.ListOptions) (int, error) {
+		repos, _, err := client.ListTeamRepositories(id, gitea.ListTeamRepositoriesOptions{ListOptions: opts})
+		for _, r := range repos {
+			names = append(names, r.Name)
+		}
+		return len(repos), err
+	})
+	return names, err
+}
+
+func listCollaboratorNames(client *gitea.Client, owner, repo string) ([]string, error) {
+	var names []string
+	err := fetchAllPages(func(opts gitea.ListOptions) (int, error) {
+		users, _, err := client.ListCollaborators(owner, repo, gitea.ListCollaboratorsOptions{ListOptions: opts})
+		for _, u := range users {
+			names = append(names, u.UserName)
+		}
+		return len(users), err
+	})
+	return names, err
+}
+
+func listRepoLabels(client *gitea.Client, owner, repo string) ([]*gitea.Label, error) {
+	var labels []*gitea.Label
+	err := fetchAllPages(func(opts gitea.ListOptions) (int, error) {
+		batch, _, err := client.ListRepoLabels(owner, repo, gitea.ListLabelsOptions{ListOptions: opts})
+		labels = append(labels, batch...)
+		return len(batch), err
+	})
+	return labels, err
+}
+
+// diffNames returns the names which have to be added to and removed from
+// current to match desired. Names are compared case insensitive.
+func diffNames(current, desired []string) (added, removed []string) {
+	for _, name := range desired {
+		if nameIndex(current, name) == -1 {
+			added = append(added, name)
+		}
+	}
+	for _, name := range current {
+		if nameIndex(desired, name) == -1 {
+			removed = append(removed, name)
+		}
+	}
+	return added, removed
+}
+
+func nameIndex(names []string, name string) int {
+	for i, n := range names {
+		if strings.EqualFold(n, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func appendDiff(diff []string, field string, current, desired interface{}) []string {
+	if fmt.Sprint(current) == fmt.Sprint(desired) {
+		return diff
+	}
+	return append(diff, fmt.Sprintf("%s: %q -> %q", field, fmt.Sprint(current), fmt.Sprint(desired)))
+}
+
+func findTeamState(teams []TeamState, name string) *TeamState {
+	for i := range teams {
+		if strings.EqualFold(teams[i].Name, name) {
+			return &teams[i]
+		}
+	}
+	return nil
+}
+
+func findLabelState(labels []LabelState, name string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// teamUnitNames converts unit types to their short form, e.g. "repo.code" -> "code"
+func teamUnitNames(units []gitea.RepoUnitType) []string {
+	names := make([]string, len(units))
+	for i, u := range units {
+		names[i] = strings.TrimPrefix(string(u), "repo.")
+	}
+	sort.Strings(names)
+	return names
+}
+
+func teamUnitTypes(names []string) []gitea.RepoUnitType {
+	units := make([]gitea.RepoUnitType, len(names))
+	for i, n := range names {
+		units[i] = gitea.RepoUnitType("repo." + n)
+	}
+	return units
+}
+
+// normalizeColor returns a color in the form "ee0701"
+func normalizeColor(color string) string {
+	return strings.ToLower(strings.TrimPrefix(color, "#"))
+}
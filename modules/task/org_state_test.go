@@ -0,0 +1,122 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffNames(t *testing.T) {
+	added, removed := diffNames([]string{"alice", "Bob"}, []string{"bob", "carol"})
+	assert.Equal(t, []string{"carol"}, added)
+	assert.Equal(t, []string{"alice"}, removed)
+
+	added, removed = diffNames(nil, nil)
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
+
+func TestReadOrgState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tea-org-state")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "org.yaml")
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`
+name: myorg
+teams:
+  - name: devs
+    permission: write
+    units: [code, pulls]
+    members: []
+repos:
+  - name: tea
+    labels:
+      - name: bug
+        color: "#EE0701"
+`), 0644))
+
+	state, err := ReadOrgState(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "myorg", state.Name)
+	assert.Nil(t, state.Settings)
+	// an empty list is managed, a missing one is not
+	assert.NotNil(t, state.Teams[0].Members)
+	assert.Nil(t, state.Teams[0].Repos)
+	assert.Nil(t, state.Repos[0].Collaborators)
+	assert.Equal(t, "ee0701", normalizeColor(state.Repos[0].Labels[0].Color))
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("name: myorg\nteam: []\n"), 0644))
+	_, err = ReadOrgState(path)
+	assert.Error(t, err)
+}
+
+func TestPlanTeams(t *testing.T) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/version":
+			fmt.Fprint(w, `{"version":"1.15.0"}`)
+		case r.URL.Path == "/api/v1/orgs/org":
+			fmt.Fprint(w, `{"id":1,"username":"org"}`)
+		case r.URL.Path == "/api/v1/orgs/org/teams" && r.URL.Query().Get("page") == "1":
+			fmt.Fprint(w, `[
+				{"id":1,"name":"Owners","permission":"owner"},
+				{"id":2,"name":"devs","permission":"write"},
+				{"id":3,"name":"old","permission":"read"}
+			]`)
+		case r.URL.Path == "/api/v1/orgs/org/teams":
+			fmt.Fprint(w, `[]`)
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client, err := gitea.NewClient(server.URL)
+	assert.NoError(t, err)
+
+	plan, err := PlanOrgState(client, &OrgState{
+		Name:  "org",
+		Teams: []TeamState{{Name: "Devs", Permission: "write"}},
+	})
+	assert.NoError(t, err)
+
+	// the owners team is never deleted, other teams missing in the state are
+	var removed []string
+	for _, c := range plan.Changes {
+		if c.Action == "-" {
+			removed = append(removed, c.Target)
+		}
+	}
+	assert.Equal(t, []string{"team old"}, removed)
+
+	assert.NoError(t, plan.Apply())
+	assert.Equal(t, []string{"/api/v1/teams/3"}, deleted)
+}
+
+func TestPlanLabels(t *testing.T) {
+	plan := &OrgPlan{Org: "org"}
+	planLabels(plan, nil, "org", RepoState{
+		Name:   "repo",
+		Labels: []LabelState{{Name: "Bug", Color: "#ee0701"}},
+	}, []*gitea.Label{{ID: 1, Name: "bug", Color: "ee0701"}})
+
+	// a label differing in case only is renamed, not recreated
+	assert.Len(t, plan.Changes, 1)
+	assert.Equal(t, "~", plan.Changes[0].Action)
+	assert.Equal(t, "label Bug of repo repo", plan.Changes[0].Target)
+}
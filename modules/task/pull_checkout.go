@@ -6,6 +6,7 @@ package task
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"code.gitea.io/sdk/gitea"
 	"code.gitea.io/tea/modules/config"
@@ -25,19 +26,83 @@ func PullCheckout(
 	index int64,
 	callback func(string) (string, error),
 ) error {
+	co, err := preparePRCheckout(login, repoOwner, repoName, index, callback)
+	if err != nil {
+		return err
+	}
+
+	return doPRCheckout(co.repo, co.pr, co.remoteName, co.remoteBranchName, co.remoteURL, forceCreateBranch)
+}
+
+// PullCheckoutWorktree creates a linked worktree at the given path, and checks
+// out the head branch of specified pull request in it. If path is empty, the
+// worktree is created next to the current one.
+func PullCheckoutWorktree(
+	login *config.Login,
+	repoOwner, repoName string,
+	forceCreateBranch bool,
+	index int64,
+	path string,
+	callback func(string) (string, error),
+) error {
+	co, err := preparePRCheckout(login, repoOwner, repoName, index, callback)
+	if err != nil {
+		return err
+	}
+
+	if len(path) == 0 {
+		root, err := co.repo.TeaRoot()
+		if err != nil {
+			return err
+		}
+		path = pullWorktreePath(root, index)
+	}
+
+	ref, info, err := resolvePRCheckoutRef(co.repo, co.pr, co.remoteName, co.remoteBranchName, co.remoteURL, forceCreateBranch)
+	if err != nil {
+		return err
+	}
+	fmt.Println(info)
+
+	fmt.Printf("Creating worktree at %s\n", path)
+	return co.repo.TeaAddWorktree(path, ref)
+}
+
+// pullWorktreePath returns the default path of the linked worktree for a PR,
+// next to the worktree at root
+func pullWorktreePath(root string, index int64) string {
+	return filepath.Join(filepath.Dir(root), fmt.Sprintf("%s-pull-%d", filepath.Base(root), index))
+}
+
+// prCheckout holds the state of a PR fetched into a local repo
+type prCheckout struct {
+	repo             *local_git.TeaRepo
+	pr               *gitea.PullRequest
+	remoteName       string
+	remoteBranchName string
+	remoteURL        string
+}
+
+// preparePRCheckout fetches the head branch of a PR into the local repo
+func preparePRCheckout(
+	login *config.Login,
+	repoOwner, repoName string,
+	index int64,
+	callback func(string) (string, error),
+) (*prCheckout, error) {
 	client := login.Client()
 	pr, _, err := client.GetPullRequest(repoOwner, repoName, index)
 	if err != nil {
-		return fmt.Errorf("couldn't fetch PR: %s", err)
+		return nil, fmt.Errorf("couldn't fetch PR: %s", err)
 	}
 	if err := workaround.FixPullHeadSha(client, pr); err != nil {
-		return err
+		return nil, err
 	}
 
 	// FIXME: should use ctx.LocalRepo..?
 	localRepo, err := local_git.RepoForWorkdir()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// find or create a matching remote
@@ -46,16 +111,21 @@ func PullCheckout(
 	// verify related remote is in local repo, otherwise add it
 	localRemote, err := localRepo.GetOrCreateRemote(remoteURL, newRemoteName)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	localRemoteName := localRemote.Config().Name
 
 	localRemoteBranchName, err := doPRFetch(login, pr, localRepo, localRemote, callback)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return doPRCheckout(localRepo, pr, localRemoteName, localRemoteBranchName, remoteURL, forceCreateBranch)
+	return &prCheckout{
+		repo:             localRepo,
+		pr:               pr,
+		remoteName:       localRemote.Config().Name,
+		remoteBranchName: localRemoteBranchName,
+		remoteURL:        remoteURL,
+	}, nil
 }
 
 func isRemoteDeleted(pr *gitea.PullRequest) bool {
@@ -67,6 +137,10 @@ func remoteURLForPR(login *config.Login, pr *gitea.PullRequest) string {
 	if isRemoteDeleted(pr) {
 		repo = pr.Base.Repository
 	}
+	return remoteURLForRepo(login, repo)
+}
+
+func remoteURLForRepo(login *config.Login, repo *gitea.Repository) string {
 	if len(login.SSHKey) != 0 {
 		// login.SSHKey is nonempty, if user specified a key manually or we automatically
 		// found a matching private key on this machine during login creation.
@@ -126,10 +200,25 @@ func doPRCheckout(
 	remoteURL string,
 	forceCreateBranch bool,
 ) error {
-	// determine the ref to checkout, depending on existence of a matching commit on a local branch
-	var info string
-	var checkoutRef git_plumbing.ReferenceName
+	checkoutRef, info, err := resolvePRCheckoutRef(localRepo, pr, localRemoteName, localRemoteBranchName, remoteURL, forceCreateBranch)
+	if err != nil {
+		return err
+	}
 
+	fmt.Println(info)
+	return localRepo.TeaCheckout(checkoutRef)
+}
+
+// resolvePRCheckoutRef determines the ref to checkout, depending on existence
+// of a matching commit on a local branch
+func resolvePRCheckoutRef(
+	localRepo *local_git.TeaRepo,
+	pr *gitea.PullRequest,
+	localRemoteName,
+	localRemoteBranchName,
+	remoteURL string,
+	forceCreateBranch bool,
+) (checkoutRef git_plumbing.ReferenceName, info string, err error) {
 	if b, _ := localRepo.TeaFindBranchBySha(pr.Head.Sha, remoteURL); b != nil {
 
 		// if a matching branch exists, use that
@@ -144,12 +233,13 @@ func doPRCheckout(
 			localBranchName += "-" + pr.Head.Ref
 		}
 		checkoutRef = git_plumbing.NewBranchReferenceName(localBranchName)
-		if err := localRepo.TeaCreateBranch(localBranchName, localRemoteBranchName, localRemoteName); err == nil {
+		if err = localRepo.TeaCreateBranch(localBranchName, localRemoteBranchName, localRemoteName); err == nil {
 			info = fmt.Sprintf("Created branch '%s'\n", localBranchName)
 		} else if err == git.ErrBranchExists {
+			err = nil
 			info = "There may be changes since you last checked out, run `git pull` to get them."
 		} else {
-			return err
+			return "", "", err
 		}
 
 	} else {
@@ -162,6 +252,5 @@ func doPRCheckout(
 
 	}
 
-	fmt.Println(info)
-	return localRepo.TeaCheckout(checkoutRef)
+	return checkoutRef, info, nil
 }
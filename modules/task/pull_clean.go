@@ -6,6 +6,10 @@ package task
 
 import (
 	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"code.gitea.io/tea/modules/config"
 	local_git "code.gitea.io/tea/modules/git"
@@ -16,18 +20,20 @@ import (
 	git_plumbing "github.com/go-git/go-git/v5/plumbing"
 )
 
+// PullBranch is a local branch that tracks the head branch of a pull request
+type PullBranch struct {
+	Pull   *gitea.PullRequest
+	Branch *git_config.Branch
+}
+
 // PullClean deletes local & remote feature-branches for a closed pull
 func PullClean(login *config.Login, repoOwner, repoName string, index int64, ignoreSHA bool, callback func(string) (string, error)) error {
 	client := login.Client()
 
-	repo, _, err := client.GetRepo(repoOwner, repoName)
+	defaultBranch, err := getDefaultBranch(client, repoOwner, repoName)
 	if err != nil {
 		return err
 	}
-	defaultBranch := repo.DefaultBranch
-	if len(defaultBranch) == 0 {
-		defaultBranch = "master"
-	}
 
 	// fetch PR source-repo & -branch from gitea
 	pr, _, err := client.GetPullRequest(repoOwner, repoName, index)
@@ -42,11 +48,8 @@ func PullClean(login *config.Login, repoOwner, repoName string, index int64, ign
 		return fmt.Errorf("PR is still open, won't delete branches")
 	}
 
-	// if remote head branch is already deleted, pr.Head.Ref points to "pulls/<idx>/head"
-	remoteBranch := pr.Head.Ref
-	remoteDeleted := remoteBranch == fmt.Sprintf("refs/pull/%d/head", pr.Index)
-	if remoteDeleted {
-		remoteBranch = pr.Head.Name // this still holds the original branch name
+	remoteBranch := pullRemoteBranchName(pr)
+	if isRemoteDeleted(pr) {
 		fmt.Printf("Remote branch '%s' already deleted.\n", remoteBranch)
 	}
 
@@ -56,16 +59,19 @@ func PullClean(login *config.Login, repoOwner, repoName string, index int64, ign
 	}
 
 	// find a branch with matching sha or name, that has a remote matching the repo url
-	var branch *git_config.Branch
-	if ignoreSHA {
-		branch, err = r.TeaFindBranchByName(remoteBranch, pr.Head.Repository.CloneURL)
-	} else {
-		branch, err = r.TeaFindBranchBySha(pr.Head.Sha, pr.Head.Repository.CloneURL)
-	}
+	branch, err := findPullBranch(r, pr, ignoreSHA)
 	if err != nil {
 		return err
 	}
 	if branch == nil {
+		// a PR may be checked out in a linked worktree without a local branch
+		removed, err := removePullWorktrees(r, pr, "")
+		if err != nil {
+			return err
+		}
+		if removed != 0 {
+			return nil
+		}
 		if ignoreSHA {
 			return fmt.Errorf("Remote branch %s not found in local repo", remoteBranch)
 		}
@@ -75,7 +81,198 @@ If you still want to continue & are sure you don't loose any important commits,
 call me again with the --ignore-sha flag`, remoteBranch)
 	}
 
+	return deletePullBranch(login, r, pr, branch, defaultBranch, callback)
+}
+
+// FindClosedPullBranches enumerates the local branches in the repo of the current
+// workdir, and returns those which belong to a closed pull of the given repo.
+// Branches created by `tea pr checkout` are resolved via the index in their name,
+// other branches by the name of their remote branch.
+func FindClosedPullBranches(login *config.Login, repoOwner, repoName string, ignoreSHA bool) ([]PullBranch, error) {
+	client := login.Client()
+
+	defaultBranch, err := getDefaultBranch(client, repoOwner, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := local_git.RepoForWorkdir()
+	if err != nil {
+		return nil, err
+	}
+
+	// branches named pulls/<idx> can be looked up directly
+	var indices []int64
+	iter, err := r.Branches()
+	if err != nil {
+		return nil, err
+	}
+	err = iter.ForEach(func(ref *git_plumbing.Reference) error {
+		if idx, ok := pullIndexFromBranchName(ref.Name().Short()); ok {
+			indices = append(indices, idx)
+		}
+		return nil
+	})
+	iter.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// all other branches need to be matched against the head branches of closed pulls
+	tracked, err := r.TeaTrackedBranchNames()
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool)
+	for _, name := range tracked {
+		if _, ok := pullIndexFromBranchName(name); !ok && name != defaultBranch {
+			wanted[name] = true
+		}
+	}
+
+	var candidates []PullBranch
+	seen := make(map[string]bool)
+	addCandidate := func(pr *gitea.PullRequest) error {
+		// the head repo may have been deleted in the meantime
+		if pr.State != gitea.StateClosed || pr.Head == nil || pr.Head.Repository == nil {
+			return nil
+		}
+		// skip PRs from repos we have no remote for
+		remote, err := r.GetRemote(pr.Head.Repository.CloneURL)
+		if err != nil || remote == nil {
+			return err
+		}
+		if err := workaround.FixPullHeadSha(client, pr); err != nil {
+			fmt.Printf("Warning: skipping PR #%d, could not determine its head commit: %s\n", pr.Index, err)
+			return nil
+		}
+		branch, err := findPullBranch(r, pr, ignoreSHA)
+		if err != nil || branch == nil || branch.Name == defaultBranch || seen[branch.Name] {
+			return err
+		}
+		seen[branch.Name] = true
+		candidates = append(candidates, PullBranch{Pull: pr, Branch: branch})
+		return nil
+	}
+
+	for _, idx := range indices {
+		pr, resp, err := client.GetPullRequest(repoOwner, repoName, idx)
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, err
+		}
+		if err := addCandidate(pr); err != nil {
+			return nil, err
+		}
+	}
+
+	// stop paging as soon as all local branches are accounted for
+	opts := gitea.ListPullRequestsOptions{
+		ListOptions: gitea.ListOptions{Page: 1, PageSize: 50},
+		State:       gitea.StateClosed,
+	}
+	for len(wanted) != 0 {
+		prs, _, err := client.ListRepoPullRequests(repoOwner, repoName, opts)
+		if err != nil {
+			return nil, err
+		}
+		if len(prs) == 0 {
+			break
+		}
+		for _, pr := range prs {
+			if pr.Head == nil || !wanted[pullRemoteBranchName(pr)] {
+				continue
+			}
+			n := len(candidates)
+			if err := addCandidate(pr); err != nil {
+				return nil, err
+			}
+			if len(candidates) > n {
+				delete(wanted, pullRemoteBranchName(pr))
+				delete(wanted, candidates[n].Branch.Name)
+			}
+		}
+		opts.Page++
+	}
+
+	return candidates, nil
+}
+
+// pullIndexFromBranchName parses the index of a pull from a branch name as
+// created by `tea pr checkout`, e.g. pulls/12 or pulls/12-feature.
+func pullIndexFromBranchName(name string) (int64, bool) {
+	if !strings.HasPrefix(name, "pulls/") {
+		return 0, false
+	}
+	name = strings.TrimPrefix(name, "pulls/")
+	if i := strings.Index(name, "-"); i >= 0 {
+		name = name[:i]
+	}
+	idx, err := strconv.ParseInt(name, 10, 64)
+	return idx, err == nil && idx > 0
+}
+
+// PullCleanBranches deletes local & remote feature-branches for the given closed pulls
+func PullCleanBranches(login *config.Login, repoOwner, repoName string, branches []PullBranch, callback func(string) (string, error)) error {
+	defaultBranch, err := getDefaultBranch(login.Client(), repoOwner, repoName)
+	if err != nil {
+		return err
+	}
+
+	r, err := local_git.RepoForWorkdir()
+	if err != nil {
+		return err
+	}
+
+	for _, b := range branches {
+		if err := deletePullBranch(login, r, b.Pull, b.Branch, defaultBranch, callback); err != nil {
+			return fmt.Errorf("could not clean branch of PR #%d: %s", b.Pull.Index, err)
+		}
+	}
+	return nil
+}
+
+func getDefaultBranch(client *gitea.Client, repoOwner, repoName string) (string, error) {
+	repo, _, err := client.GetRepo(repoOwner, repoName)
+	if err != nil {
+		return "", err
+	}
+	if len(repo.DefaultBranch) == 0 {
+		return "master", nil
+	}
+	return repo.DefaultBranch, nil
+}
+
+// pullRemoteBranchName returns the name of the head branch of the PR on its remote.
+func pullRemoteBranchName(pr *gitea.PullRequest) string {
+	// if remote head branch is already deleted, pr.Head.Ref points to "pulls/<idx>/head"
+	if isRemoteDeleted(pr) {
+		return pr.Head.Name // this still holds the original branch name
+	}
+	return pr.Head.Ref
+}
+
+func findPullBranch(r *local_git.TeaRepo, pr *gitea.PullRequest, ignoreSHA bool) (*git_config.Branch, error) {
+	if ignoreSHA {
+		return r.TeaFindBranchByName(pullRemoteBranchName(pr), pr.Head.Repository.CloneURL)
+	}
+	return r.TeaFindBranchBySha(pr.Head.Sha, pr.Head.Repository.CloneURL)
+}
+
+func deletePullBranch(
+	login *config.Login,
+	r *local_git.TeaRepo,
+	pr *gitea.PullRequest,
+	branch *git_config.Branch,
+	defaultBranch string,
+	callback func(string) (string, error),
+) error {
 	// prepare deletion of local branch:
+	if _, err := removePullWorktrees(r, pr, branch.Name); err != nil {
+		return err
+	}
 	headRef, err := r.Head()
 	if err != nil {
 		return err
@@ -90,12 +287,12 @@ call me again with the --ignore-sha flag`, remoteBranch)
 
 	// remove local & remote branch
 	fmt.Printf("Deleting local branch %s\n", branch.Name)
-	err = r.TeaDeleteLocalBranch(branch)
-	if err != nil {
+	if err = r.TeaDeleteLocalBranch(branch); err != nil {
 		return err
 	}
 
-	if !remoteDeleted && pr.Head.Repository.Permissions.Push {
+	remoteBranch := pullRemoteBranchName(pr)
+	if !isRemoteDeleted(pr) && pr.Head.Repository.Permissions.Push {
 		fmt.Printf("Deleting remote branch %s\n", remoteBranch)
 		url, err := r.TeaRemoteURL(branch.Remote)
 		if err != nil {
@@ -105,7 +302,38 @@ call me again with the --ignore-sha flag`, remoteBranch)
 		if err != nil {
 			return err
 		}
-		err = r.TeaDeleteRemoteBranch(branch.Remote, remoteBranch, auth)
+		return r.TeaDeleteRemoteBranch(branch.Remote, remoteBranch, auth)
+	}
+	return nil
+}
+
+// removePullWorktrees removes all linked worktrees which have the given branch
+// checked out, and the worktree tea created for the PR by default, if it has
+// the head commit of the PR checked out in detached state.
+func removePullWorktrees(r *local_git.TeaRepo, pr *gitea.PullRequest, branchName string) (removed int, err error) {
+	trees, err := r.TeaWorktrees()
+	if err != nil {
+		return 0, err
+	}
+	root, err := r.TeaRoot()
+	if err != nil {
+		return 0, err
+	}
+	teaPath := pullWorktreePath(root, pr.Index)
+
+	for _, tree := range trees {
+		branchMatch := len(branchName) != 0 && tree.Branch == branchName
+		// other detached worktrees of the same commit may be unrelated to tea
+		detachedMatch := len(tree.Branch) == 0 && tree.Head == pr.Head.Sha &&
+			filepath.Clean(tree.Path) == teaPath
+		if !branchMatch && !detachedMatch {
+			continue
+		}
+		fmt.Printf("Removing worktree %s\n", tree.Path)
+		if err = r.TeaRemoveWorktree(tree.Path); err != nil {
+			return removed, err
+		}
+		removed++
 	}
-	return err
+	return removed, nil
 }
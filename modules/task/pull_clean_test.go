@@ -0,0 +1,27 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullIndexFromBranchName(t *testing.T) {
+	for name, want := range map[string]int64{
+		"pulls/12":            12,
+		"pulls/12-feature":    12,
+		"pulls/3-fix-foo-bar": 3,
+		"pulls/feature":       0,
+		"pulls/0":             0,
+		"feature/pulls/12":    0,
+		"main":                0,
+	} {
+		idx, ok := pullIndexFromBranchName(name)
+		assert.Equal(t, want != 0, ok, name)
+		assert.Equal(t, want, idx, name)
+	}
+}
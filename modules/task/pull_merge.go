@@ -0,0 +1,142 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"fmt"
+	"time"
+
+	"code.gitea.io/tea/modules/config"
+	local_git "code.gitea.io/tea/modules/git"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/go-git/go-git/v5"
+	git_config "github.com/go-git/go-git/v5/config"
+	git_plumbing "github.com/go-git/go-git/v5/plumbing"
+	git_transport "github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// PullMergeLocal merges a PR locally via git, and pushes the result to the base
+// branch. This allows for merge commits to be signed with local keys.
+// Afterwards it is verified, that Gitea detected the PR as merged.
+func PullMergeLocal(
+	login *config.Login,
+	repoOwner, repoName string,
+	index int64,
+	style gitea.MergeStyle,
+	title, message string,
+	sign bool,
+	callback func(string) (string, error),
+) error {
+	// fetch the head branch
+	co, err := preparePRCheckout(login, repoOwner, repoName, index, callback)
+	if err != nil {
+		return err
+	}
+	pr := co.pr
+	if pr.State != gitea.StateOpen {
+		return fmt.Errorf("PR is not open, won't merge")
+	}
+
+	// fetch the base branch
+	baseURL := remoteURLForRepo(login, pr.Base.Repository)
+	baseRemote, err := co.repo.GetOrCreateRemote(baseURL, fmt.Sprintf("pulls/%v", pr.Base.Repository.Owner.UserName))
+	if err != nil {
+		return err
+	}
+	baseRemoteName := baseRemote.Config().Name
+	auth, err := remoteAuth(login, co.repo, baseRemoteName, callback)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Fetching base branch '%s' from remote '%s'\n", pr.Base.Ref, baseRemoteName)
+	err = baseRemote.Fetch(&git.FetchOptions{
+		Auth: auth,
+		RefSpecs: []git_config.RefSpec{git_config.RefSpec(fmt.Sprintf("+%s:%s",
+			git_plumbing.NewBranchReferenceName(pr.Base.Ref),
+			git_plumbing.NewRemoteReferenceName(baseRemoteName, pr.Base.Ref),
+		))},
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return err
+	}
+
+	// merge locally into a temporary branch
+	if len(title) == 0 {
+		title = defaultMergeTitle(pr, style)
+	}
+	if len(message) != 0 {
+		title += "\n\n" + message
+	}
+	mergeBranch := fmt.Sprintf("tea/merge-pull-%d", pr.Index)
+
+	fmt.Printf("Merging PR %d into '%s' locally (style: %s)\n", pr.Index, pr.Base.Ref, style)
+	err = co.repo.TeaMergeLocal(
+		mergeBranch,
+		git_plumbing.NewRemoteReferenceName(baseRemoteName, pr.Base.Ref),
+		git_plumbing.NewRemoteReferenceName(co.remoteName, co.remoteBranchName),
+		string(style),
+		title,
+		sign,
+	)
+	if err != nil {
+		return err
+	}
+	defer co.repo.TeaDeleteLocalBranch(&git_config.Branch{Name: mergeBranch})
+
+	// push the result
+	fmt.Printf("Pushing to '%s' on remote '%s'\n", pr.Base.Ref, baseRemoteName)
+	err = co.repo.Push(&git.PushOptions{
+		RemoteName: baseRemoteName,
+		RefSpecs: []git_config.RefSpec{git_config.RefSpec(fmt.Sprintf("%s:%s",
+			git_plumbing.NewBranchReferenceName(mergeBranch),
+			git_plumbing.NewBranchReferenceName(pr.Base.Ref),
+		))},
+		Auth: auth,
+	})
+	if err != nil {
+		return err
+	}
+
+	return waitForPullMerged(login.Client(), repoOwner, repoName, pr.Index, style)
+}
+
+// waitForPullMerged polls the server until the PR is detected as merged.
+// Gitea processes pushes asynchronously, so this might take a moment.
+func waitForPullMerged(client *gitea.Client, repoOwner, repoName string, index int64, style gitea.MergeStyle) error {
+	for i := 0; i < 10; i++ {
+		merged, _, err := client.IsPullRequestMerged(repoOwner, repoName, index)
+		if err != nil {
+			return err
+		}
+		if merged {
+			fmt.Printf("PR %d is merged\n", index)
+			return nil
+		}
+		time.Sleep(time.Second)
+	}
+
+	if style != gitea.MergeStyleMerge {
+		return fmt.Errorf(`Pushed, but Gitea did not mark PR %d as merged.
+With style '%s' the commits of the PR are rewritten, which Gitea can't detect.
+Please close the PR manually.`, index, style)
+	}
+	return fmt.Errorf("Pushed, but Gitea did not mark PR %d as merged", index)
+}
+
+func defaultMergeTitle(pr *gitea.PullRequest, style gitea.MergeStyle) string {
+	if style == gitea.MergeStyleSquash {
+		return fmt.Sprintf("%s (#%d)", pr.Title, pr.Index)
+	}
+	return fmt.Sprintf("Merge pull request '%s' (#%d) from %s into %s", pr.Title, pr.Index, pr.Head.Ref, pr.Base.Ref)
+}
+
+func remoteAuth(login *config.Login, repo *local_git.TeaRepo, remoteName string, callback func(string) (string, error)) (git_transport.AuthMethod, error) {
+	url, err := repo.TeaRemoteURL(remoteName)
+	if err != nil {
+		return nil, err
+	}
+	return local_git.GetAuthForURL(url, login.Token, login.SSHKey, callback)
+}
@@ -0,0 +1,28 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// EditRepo applies the given settings to a repo and prints the result
+func EditRepo(login *config.Login, owner, repo string, opts gitea.EditRepoOption) error {
+	client := login.Client()
+	r, _, err := client.EditRepo(owner, repo, opts)
+	if err != nil {
+		return err
+	}
+
+	topics, _, err := client.ListRepoTopics(r.Owner.UserName, r.Name, gitea.ListRepoTopicsOptions{})
+	if err != nil {
+		return err
+	}
+	print.RepoDetails(r, topics)
+	return nil
+}
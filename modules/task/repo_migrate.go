@@ -0,0 +1,87 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// MigrationSource describes a repo to migrate, as read from a batch file
+type MigrationSource struct {
+	CloneAddr string
+	RepoName  string
+}
+
+// ReadMigrationSources reads a batch file listing one source repo per line, in
+// the format `<clone url> [<repo name>]`. Empty lines and lines starting with # are ignored.
+func ReadMigrationSources(path string) ([]MigrationSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseMigrationSources(f)
+}
+
+func parseMigrationSources(r io.Reader) ([]MigrationSource, error) {
+	var sources []MigrationSource
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) > 2 {
+			return nil, fmt.Errorf("line %d: expected '<clone url> [<repo name>]'", line)
+		}
+		source := MigrationSource{CloneAddr: fields[0], RepoName: RepoNameFromURL(fields[0])}
+		if len(fields) == 2 {
+			source.RepoName = fields[1]
+		}
+		sources = append(sources, source)
+	}
+	return sources, scanner.Err()
+}
+
+// RepoNameFromURL derives a repo name from a clone URL
+func RepoNameFromURL(cloneURL string) string {
+	name := strings.TrimSuffix(strings.TrimRight(cloneURL, "/"), ".git")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// MigrateRepos migrates all given sources using the template options, continuing on errors.
+// Progress and a summary are printed to stdout.
+func MigrateRepos(client *gitea.Client, template gitea.MigrateRepoOption, sources []MigrationSource) error {
+	failed := 0
+	for i, source := range sources {
+		opts := template
+		opts.CloneAddr = source.CloneAddr
+		opts.RepoName = source.RepoName
+
+		fmt.Printf("[%d/%d] %s -> %s ", i+1, len(sources), source.CloneAddr, source.RepoName)
+		repo, _, err := client.MigrateRepo(opts)
+		if err != nil {
+			failed++
+			fmt.Printf("failed: %s\n", err)
+			continue
+		}
+		fmt.Printf("%s\n", repo.HTMLURL)
+	}
+
+	fmt.Printf("\nMigrated %d of %d repos\n", len(sources)-failed, len(sources))
+	if failed != 0 {
+		return fmt.Errorf("%d migrations failed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoNameFromURL(t *testing.T) {
+	assert.Equal(t, "tea", RepoNameFromURL("https://gitea.com/gitea/tea.git"))
+	assert.Equal(t, "tea", RepoNameFromURL("https://gitea.com/gitea/tea/"))
+	assert.Equal(t, "tea", RepoNameFromURL("git@gitea.com:gitea/tea.git"))
+	assert.Equal(t, "tea", RepoNameFromURL("tea"))
+}
+
+func TestParseMigrationSources(t *testing.T) {
+	sources, err := parseMigrationSources(strings.NewReader(`
+# comment
+https://github.com/go-gitea/gitea.git
+https://gitlab.com/foo/bar   baz
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []MigrationSource{
+		{CloneAddr: "https://github.com/go-gitea/gitea.git", RepoName: "gitea"},
+		{CloneAddr: "https://gitlab.com/foo/bar", RepoName: "baz"},
+	}, sources)
+
+	_, err = parseMigrationSources(strings.NewReader("a b c\n"))
+	assert.EqualError(t, err, "line 1: expected '<clone url> [<repo name>]'")
+}
@@ -0,0 +1,25 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"code.gitea.io/sdk/gitea"
+)
+
+// SearchAllRepos fetches all repos matching the search options, iterating over all pages
+func SearchAllRepos(client *gitea.Client, opts gitea.SearchRepoOptions) ([]*gitea.Repository, error) {
+	var repos []*gitea.Repository
+	opts.PageSize = 50
+	for opts.Page = 1; ; opts.Page++ {
+		batch, _, err := client.SearchRepos(opts)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			return repos, nil
+		}
+		repos = append(repos, batch...)
+	}
+}
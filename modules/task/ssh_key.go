@@ -0,0 +1,125 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"code.gitea.io/tea/modules/utils"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// ReadSSHPublicKey reads a public key in authorized_keys format from a file
+func ReadSSHPublicKey(path string) (key ssh.PublicKey, content string, err error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if key, _, _, _, err = ssh.ParseAuthorizedKey(raw); err != nil {
+		return nil, "", fmt.Errorf("%s is no valid ssh public key: %s", path, err)
+	}
+	return key, strings.TrimSpace(string(raw)), nil
+}
+
+// GenerateSSHKey creates a new ed25519 keypair, and stores it at path & path.pub.
+// Existing files are not overwritten.
+func GenerateSSHKey(path, comment string) (key ssh.PublicKey, content string, err error) {
+	for _, p := range []string{path, path + ".pub"} {
+		exists, err := utils.FileExist(p)
+		if err != nil {
+			return nil, "", err
+		}
+		if exists {
+			return nil, "", fmt.Errorf("%s already exists", p)
+		}
+	}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, "", err
+	}
+	if key, err = ssh.NewPublicKey(pub); err != nil {
+		return nil, "", err
+	}
+	privPEM, err := marshalED25519PrivateKey(priv, comment)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content = strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+	if len(comment) != 0 {
+		content += " " + comment
+	}
+	if err = ioutil.WriteFile(path, privPEM, 0600); err != nil {
+		return nil, "", err
+	}
+	if err = ioutil.WriteFile(path+".pub", []byte(content+"\n"), 0644); err != nil {
+		return nil, "", err
+	}
+	return key, content, nil
+}
+
+// marshalED25519PrivateKey encodes an unencrypted private key in the openssh-key-v1
+// format used by OpenSSH. The vendored x/crypto/ssh can parse this format, but not write it.
+func marshalED25519PrivateKey(key ed25519.PrivateKey, comment string) ([]byte, error) {
+	pub, err := ssh.NewPublicKey(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	var check [4]byte
+	if _, err = rand.Read(check[:]); err != nil {
+		return nil, err
+	}
+	checkInt := binary.BigEndian.Uint32(check[:])
+
+	privBlock := struct {
+		Check1  uint32
+		Check2  uint32
+		Keytype string
+		Pub     []byte
+		Priv    []byte
+		Comment string
+		Pad     []byte `ssh:"rest"`
+	}{
+		Check1:  checkInt,
+		Check2:  checkInt,
+		Keytype: ssh.KeyAlgoED25519,
+		Pub:     key.Public().(ed25519.PublicKey),
+		Priv:    key,
+		Comment: comment,
+	}
+	// pad to the block size of 8 of the unencrypted "none" cipher
+	for i := 1; (len(ssh.Marshal(privBlock)))%8 != 0; i++ {
+		privBlock.Pad = append(privBlock.Pad, byte(i))
+	}
+
+	body := struct {
+		CipherName   string
+		KdfName      string
+		KdfOpts      string
+		NumKeys      uint32
+		PubKey       []byte
+		PrivKeyBlock []byte
+	}{
+		CipherName:   "none",
+		KdfName:      "none",
+		NumKeys:      1,
+		PubKey:       pub.Marshal(),
+		PrivKeyBlock: ssh.Marshal(privBlock),
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "OPENSSH PRIVATE KEY",
+		Bytes: append([]byte("openssh-key-v1\x00"), ssh.Marshal(body)...),
+	}), nil
+}
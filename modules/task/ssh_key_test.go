@@ -0,0 +1,26 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestMarshalED25519PrivateKey(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	pemBytes, err := marshalED25519PrivateKey(priv, "tea@example.com")
+	assert.NoError(t, err)
+
+	parsed, err := ssh.ParseRawPrivateKey(pemBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, &priv, parsed)
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// ListAllOrgTeams fetches all teams of an organization, iterating over all pages.
+// The response of the last request is returned, to check the status of failures.
+func ListAllOrgTeams(client *gitea.Client, org string) ([]*gitea.Team, *gitea.Response, error) {
+	var teams []*gitea.Team
+	for page := 1; ; page++ {
+		batch, resp, err := client.ListOrgTeams(org, gitea.ListTeamsOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: 50},
+		})
+		if err != nil {
+			return nil, resp, err
+		}
+		if len(batch) == 0 {
+			return teams, resp, nil
+		}
+		teams = append(teams, batch...)
+	}
+}
+
+// ListAllOrgMembers fetches all members of an organization, iterating over all pages
+func ListAllOrgMembers(client *gitea.Client, org string) ([]*gitea.User, error) {
+	var members []*gitea.User
+	for page := 1; ; page++ {
+		batch, _, err := client.ListOrgMembership(org, gitea.ListOrgMembershipOption{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: 50},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			return members, nil
+		}
+		members = append(members, batch...)
+	}
+}
+
+// FindTeams looks up teams of an organization by their name
+func FindTeams(client *gitea.Client, org string, names []string) ([]*gitea.Team, error) {
+	teams, _, err := ListAllOrgTeams(client, org)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*gitea.Team, 0, len(names))
+	for _, name := range names {
+		team := findTeam(teams, name)
+		if team == nil {
+			return nil, fmt.Errorf("team '%s' does not exist in organization '%s'", name, org)
+		}
+		result = append(result, team)
+	}
+	return result, nil
+}
+
+func findTeam(teams []*gitea.Team, name string) *gitea.Team {
+	for _, t := range teams {
+		if strings.EqualFold(t.Name, name) {
+			return t
+		}
+	}
+	return nil
+}
+
+// FindTeam looks up a team of an organization by its name
+func FindTeam(client *gitea.Client, org, name string) (*gitea.Team, error) {
+	teams, err := FindTeams(client, org, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	return teams[0], nil
+}
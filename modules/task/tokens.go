@@ -0,0 +1,30 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"code.gitea.io/tea/modules/config"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// BasicAuthClient returns a client for the server of login, which authenticates
+// with username & password instead of the token, as required to manage tokens.
+// ErrOTPRequired is returned if an OTP is required, but was not given.
+func BasicAuthClient(login *config.Login, user, pass string) (*gitea.Client, error) {
+	client := login.Client(gitea.SetBasicAuth(user, pass))
+
+	// verify the credentials, to detect whether an OTP is required
+	_, resp, err := client.ListAccessTokens(gitea.ListAccessTokensOptions{
+		ListOptions: gitea.ListOptions{PageSize: 1},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 401 && len(login.OTP) == 0 && isOTPRequired(login, user, pass) {
+			return nil, ErrOTPRequired
+		}
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,317 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// UserImport describes a user account to be created or updated by ImportUsers
+type UserImport struct {
+	Username string
+	Email    string
+	// FullName is left unchanged for existing users if it is empty
+	FullName string
+	Password string
+	// MustChangePassword only applies to new users, as it can't be read for existing ones
+	MustChangePassword bool
+	// Teams are given in the form "<org>/<team>"
+	Teams []string
+}
+
+// UserImportSummary counts the results of ImportUsers
+type UserImportSummary struct {
+	Created     int
+	Updated     int
+	Unchanged   int
+	Memberships int
+	Failed      int
+}
+
+// userImportColumns are the columns understood in a users CSV file
+var userImportColumns = []string{"username", "email", "full_name", "password", "must_change_password", "teams"}
+
+// ReadUserImports parses a CSV or, if the file name ends with .ldif, an LDIF
+// file of users. The first line of CSV files has to name the columns, of which
+// username and email are required.
+func ReadUserImports(path string) ([]UserImport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	parse := parseUserImports
+	if strings.EqualFold(filepath.Ext(path), ".ldif") {
+		parse = parseLDIFUserImports
+	}
+	users, err := parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse '%s': %s", path, err)
+	}
+	return users, nil
+}
+
+func parseUserImports(r io.Reader) ([]UserImport, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing header: %s", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if nameIndex(userImportColumns, name) == -1 {
+			return nil, fmt.Errorf("unknown column '%s', available are %s", name, strings.Join(userImportColumns, ", "))
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"username", "email"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing column '%s'", required)
+		}
+	}
+
+	var users []UserImport
+	seen := map[string]bool{}
+	for entry := 1; ; entry++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return users, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		get := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		user := UserImport{
+			Username:           get("username"),
+			Email:              get("email"),
+			FullName:           get("full_name"),
+			Password:           get("password"),
+			MustChangePassword: true,
+			Teams:              strings.FieldsFunc(get("teams"), isTeamSeparator),
+		}
+		if len(user.Username) == 0 || len(user.Email) == 0 {
+			return nil, fmt.Errorf("entry %d: username and email are required", entry)
+		}
+		if seen[strings.ToLower(user.Username)] {
+			return nil, fmt.Errorf("entry %d: duplicate user '%s'", entry, user.Username)
+		}
+		seen[strings.ToLower(user.Username)] = true
+		if value := get("must_change_password"); len(value) != 0 {
+			if user.MustChangePassword, err = strconv.ParseBool(value); err != nil {
+				return nil, fmt.Errorf("entry %d: invalid must_change_password '%s'", entry, value)
+			}
+		}
+		for _, team := range user.Teams {
+			if strings.Count(team, "/") != 1 {
+				return nil, fmt.Errorf("entry %d: team '%s' is not in the form <org>/<team>", entry, team)
+			}
+		}
+		users = append(users, user)
+	}
+}
+
+func isTeamSeparator(r rune) bool {
+	return r == ';' || r == ' '
+}
+
+// ImportUsers creates missing users, updates email and full name of existing
+// ones and adds them to their teams. Changes are printed as they happen, and
+// only printed if dryRun is set. Generated passwords are written as CSV to
+// passwords. Failures are reported, but don't stop the import.
+func ImportUsers(client *gitea.Client, users []UserImport, dryRun bool, passwords io.Writer) (*UserImportSummary, error) {
+	summary := &UserImportSummary{}
+	teams := newTeamCache(client)
+
+	var passwordWriter *csv.Writer
+	if passwords != nil {
+		passwordWriter = csv.NewWriter(passwords)
+	}
+
+	for _, u := range users {
+		if err := importUser(client, u, dryRun, passwordWriter, summary); err != nil {
+			summary.Failed++
+			fmt.Printf("! %s: %s\n", u.Username, err)
+			continue
+		}
+
+		for _, name := range u.Teams {
+			added, err := teams.addMember(name, u.Username, dryRun)
+			if err != nil {
+				summary.Failed++
+				fmt.Printf("! %s: could not add to %s: %s\n", u.Username, name, err)
+				continue
+			}
+			if added {
+				summary.Memberships++
+				fmt.Printf("+ %s to team %s\n", u.Username, name)
+			}
+		}
+	}
+
+	if passwordWriter != nil {
+		passwordWriter.Flush()
+		return summary, passwordWriter.Error()
+	}
+	return summary, nil
+}
+
+func importUser(client *gitea.Client, u UserImport, dryRun bool, passwords *csv.Writer, summary *UserImportSummary) error {
+	existing, resp, err := client.GetUserInfo(u.Username)
+	if resp != nil && resp.StatusCode == 404 {
+		if err = createImportedUser(client, u, dryRun, passwords); err != nil {
+			return err
+		}
+		summary.Created++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	opts, diff := userImportChanges(existing, u)
+	if len(diff) == 0 {
+		summary.Unchanged++
+		return nil
+	}
+
+	if !dryRun {
+		if _, err = client.AdminEditUser(u.Username, opts); err != nil {
+			return err
+		}
+	}
+	summary.Updated++
+	fmt.Printf("~ %s: %s\n", u.Username, strings.Join(diff, ", "))
+	return nil
+}
+
+// userImportChanges returns the options to update an existing user to the imported
+// one, and a description of the changes. Empty fields are left unchanged.
+func userImportChanges(existing *gitea.User, u UserImport) (gitea.EditUserOption, []string) {
+	// login name is required by the API, source 0 leaves the auth source unchanged
+	opts := gitea.EditUserOption{LoginName: u.Username}
+	var diff []string
+
+	if existing.Email != u.Email {
+		diff = appendDiff(diff, "email", existing.Email, u.Email)
+		opts.Email = &u.Email
+	}
+	if len(u.FullName) != 0 && existing.FullName != u.FullName {
+		diff = appendDiff(diff, "full_name", existing.FullName, u.FullName)
+		opts.FullName = &u.FullName
+	}
+	return opts, diff
+}
+
+func createImportedUser(client *gitea.Client, u UserImport, dryRun bool, passwords *csv.Writer) error {
+	if dryRun {
+		fmt.Printf("+ %s <%s>\n", u.Username, u.Email)
+		return nil
+	}
+
+	password := u.Password
+	generated := len(password) == 0
+	if generated {
+		var err error
+		if password, err = GeneratePassword(16); err != nil {
+			return err
+		}
+	}
+
+	_, _, err := client.AdminCreateUser(gitea.CreateUserOption{
+		Username:           u.Username,
+		Email:              u.Email,
+		FullName:           u.FullName,
+		Password:           password,
+		MustChangePassword: &u.MustChangePassword,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case generated && passwords != nil:
+		fmt.Printf("+ %s <%s>\n", u.Username, u.Email)
+		return passwords.Write([]string{u.Username, password})
+	case generated:
+		fmt.Printf("+ %s <%s> (password: %s)\n", u.Username, u.Email, password)
+	default:
+		fmt.Printf("+ %s <%s>\n", u.Username, u.Email)
+	}
+	return nil
+}
+
+// teamCache looks up teams and their members once per team
+type teamCache struct {
+	client  *gitea.Client
+	teams   map[string][]*gitea.Team
+	members map[int64][]string
+}
+
+func newTeamCache(client *gitea.Client) *teamCache {
+	return &teamCache{
+		client:  client,
+		teams:   map[string][]*gitea.Team{},
+		members: map[int64][]string{},
+	}
+}
+
+// addMember adds user to the team given as "<org>/<team>", and
+// returns whether it wasn't a member before
+func (c *teamCache) addMember(name, user string, dryRun bool) (bool, error) {
+	parts := strings.SplitN(name, "/", 2)
+	org := strings.ToLower(parts[0])
+
+	teams, ok := c.teams[org]
+	if !ok {
+		var err error
+		if teams, _, err = ListAllOrgTeams(c.client, org); err != nil {
+			return false, err
+		}
+		c.teams[org] = teams
+	}
+	team := findTeam(teams, parts[1])
+	if team == nil {
+		return false, fmt.Errorf("team does not exist")
+	}
+
+	members, ok := c.members[team.ID]
+	if !ok {
+		var err error
+		if members, err = listTeamMemberNames(c.client, team.ID); err != nil {
+			return false, err
+		}
+		c.members[team.ID] = members
+	}
+	if nameIndex(members, user) != -1 {
+		return false, nil
+	}
+
+	if !dryRun {
+		if _, err := c.client.AddTeamMember(team.ID, user); err != nil {
+			return false, err
+		}
+	}
+	c.members[team.ID] = append(members, user)
+	return true, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// parseLDIFUserImports reads users from the content records of an LDIF export
+// (RFC 2849). uid is used as username, mail as email, and displayName or cn as
+// full name. Entries without uid, like groups or organizational units, are
+// skipped. Passwords and team memberships are not read from LDIF.
+func parseLDIFUserImports(r io.Reader) ([]UserImport, error) {
+	entries, err := parseLDIF(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []UserImport
+	seen := map[string]bool{}
+	for _, e := range entries {
+		if _, ok := e.attrs["changetype"]; ok {
+			return nil, fmt.Errorf("entry %s: change records are not supported", e.dn)
+		}
+		user := UserImport{
+			Username:           e.get("uid"),
+			Email:              e.get("mail"),
+			FullName:           e.get("displayname"),
+			MustChangePassword: true,
+		}
+		if len(user.Username) == 0 {
+			continue
+		}
+		if len(user.FullName) == 0 {
+			user.FullName = e.get("cn")
+		}
+		if len(user.Email) == 0 {
+			return nil, fmt.Errorf("entry %s: mail is required", e.dn)
+		}
+		if seen[strings.ToLower(user.Username)] {
+			return nil, fmt.Errorf("entry %s: duplicate user '%s'", e.dn, user.Username)
+		}
+		seen[strings.ToLower(user.Username)] = true
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+type ldifEntry struct {
+	dn string
+	// attrs maps lower case attribute names to their values
+	attrs map[string][]string
+}
+
+// get returns the first value of an attribute
+func (e ldifEntry) get(attr string) string {
+	if values := e.attrs[attr]; len(values) != 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// parseLDIF splits LDIF content into entries, unfolding continued lines and
+// decoding base64 values. Values referenced by URL are not supported.
+func parseLDIF(r io.Reader) ([]ldifEntry, error) {
+	var (
+		entries []ldifEntry
+		lines   []string
+	)
+	flush := func() error {
+		if len(lines) == 0 {
+			return nil
+		}
+		e := ldifEntry{attrs: map[string][]string{}}
+		for _, line := range lines {
+			i := strings.Index(line, ":")
+			if i <= 0 {
+				return fmt.Errorf("invalid line '%s'", line)
+			}
+			attr, value := strings.ToLower(strings.TrimSpace(line[:i])), line[i+1:]
+			// the attribute description may carry options, e.g. cn;lang-de
+			if j := strings.Index(attr, ";"); j >= 0 {
+				attr = attr[:j]
+			}
+			switch {
+			case strings.HasPrefix(value, ":"):
+				decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value[1:]))
+				if err != nil {
+					return fmt.Errorf("invalid base64 value of %s: %s", attr, err)
+				}
+				value = string(decoded)
+			case strings.HasPrefix(value, "<"):
+				return fmt.Errorf("values referenced by URL are not supported (%s)", attr)
+			default:
+				value = strings.TrimLeft(value, " ")
+			}
+			if attr == "dn" {
+				e.dn = value
+				continue
+			}
+			e.attrs[attr] = append(e.attrs[attr], value)
+		}
+		lines = nil
+		// a version line may precede the first entry
+		if len(e.dn) == 0 {
+			if _, ok := e.attrs["version"]; ok && len(e.attrs) == 1 {
+				return nil
+			}
+			return fmt.Errorf("entry without dn")
+		}
+		entries = append(entries, e)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case len(strings.TrimSpace(line)) == 0:
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, " "):
+			if len(lines) == 0 {
+				return nil, fmt.Errorf("continued line without preceding line")
+			}
+			lines[len(lines)-1] += line[1:]
+		default:
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
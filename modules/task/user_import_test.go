@@ -0,0 +1,132 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"strings"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUserImports(t *testing.T) {
+	users, err := parseUserImports(strings.NewReader(`username,email,full_name,must_change_password,teams
+# students of the first semester
+alice,alice@example.com,Alice A.,,cs/students
+bob, bob@example.com,"Bob, B.",false,"cs/students;cs/tutors"
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []UserImport{
+		{
+			Username:           "alice",
+			Email:              "alice@example.com",
+			FullName:           "Alice A.",
+			MustChangePassword: true,
+			Teams:              []string{"cs/students"},
+		},
+		{
+			Username:           "bob",
+			Email:              "bob@example.com",
+			FullName:           "Bob, B.",
+			MustChangePassword: false,
+			Teams:              []string{"cs/students", "cs/tutors"},
+		},
+	}, users)
+
+	for _, input := range []string{
+		"",
+		"username,full_name\nalice,Alice\n",
+		"username,email,shell\nalice,alice@example.com,bash\n",
+		"username,email\nalice,\n",
+		"username,email\nalice,a@example.com\nAlice,b@example.com\n",
+		"username,email,must_change_password\nalice,a@example.com,maybe\n",
+		"username,email,teams\nalice,a@example.com,students\n",
+	} {
+		_, err = parseUserImports(strings.NewReader(input))
+		assert.Error(t, err, input)
+	}
+}
+
+func TestUserImportChanges(t *testing.T) {
+	existing := &gitea.User{UserName: "alice", Email: "alice@example.com", FullName: "Alice Liddell"}
+
+	// without a full_name column, the full name must not be cleared
+	users, err := parseUserImports(strings.NewReader("username,email\nalice,alice@example.com\n"))
+	assert.NoError(t, err)
+	opts, diff := userImportChanges(existing, users[0])
+	assert.Empty(t, diff)
+	assert.Nil(t, opts.FullName)
+	assert.Nil(t, opts.Email)
+
+	users, err = parseUserImports(strings.NewReader("username,email\nalice,alice@new.example.com\n"))
+	assert.NoError(t, err)
+	opts, diff = userImportChanges(existing, users[0])
+	assert.Len(t, diff, 1)
+	assert.Nil(t, opts.FullName)
+	if assert.NotNil(t, opts.Email) {
+		assert.Equal(t, "alice@new.example.com", *opts.Email)
+	}
+	assert.Equal(t, "alice", opts.LoginName)
+
+	users, err = parseUserImports(strings.NewReader("username,email,full_name\nalice,alice@example.com,Alice L.\n"))
+	assert.NoError(t, err)
+	opts, diff = userImportChanges(existing, users[0])
+	assert.Len(t, diff, 1)
+	assert.Nil(t, opts.Email)
+	if assert.NotNil(t, opts.FullName) {
+		assert.Equal(t, "Alice L.", *opts.FullName)
+	}
+}
+
+func TestParseLDIFUserImports(t *testing.T) {
+	users, err := parseLDIFUserImports(strings.NewReader(`version: 1
+
+# groups are skipped
+dn: ou=people,dc=example,dc=com
+objectClass: organizationalUnit
+ou: people
+
+dn: uid=alice,ou=people,dc=example,dc=com
+objectClass: inetOrgPerson
+uid: alice
+cn: Alice Liddell
+mail: alice@exa
+ mple.com
+
+dn: uid=bob,ou=people,dc=example,dc=com
+uid: bob
+cn: Bob
+displayName:: Qm9iIELDpGNrZXI=
+mail: bob@example.com
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []UserImport{
+		{
+			Username:           "alice",
+			Email:              "alice@example.com",
+			FullName:           "Alice Liddell",
+			MustChangePassword: true,
+		},
+		{
+			Username:           "bob",
+			Email:              "bob@example.com",
+			FullName:           "Bob Bäcker",
+			MustChangePassword: true,
+		},
+	}, users)
+
+	for _, input := range []string{
+		"dn: uid=alice,dc=example\nuid: alice\n",
+		"dn: uid=alice,dc=example\nuid: alice\nmail: a@example.com\n\ndn: uid=Alice,dc=example\nuid: Alice\nmail: b@example.com\n",
+		"dn: uid=alice,dc=example\nchangetype: delete\n",
+		"dn: uid=alice,dc=example\nuid: alice\nmail:< file:///etc/passwd\n",
+		"uid: alice\nmail: a@example.com\n",
+		"dn: uid=alice,dc=example\nuid alice\n",
+	} {
+		_, err := parseLDIFUserImports(strings.NewReader(input))
+		assert.Error(t, err, input)
+	}
+}
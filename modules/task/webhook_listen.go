@@ -0,0 +1,189 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+
+	"code.gitea.io/tea/modules/print"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// WebhookListener receives webhook events via HTTP, prints them and optionally
+// passes them on to another URL or a command
+type WebhookListener struct {
+	// Secret validates the X-Gitea-Signature header of requests, if set
+	Secret string
+	// ForwardURL receives a copy of each valid request, if set
+	ForwardURL string
+	// Command is run with the payload on stdin for each valid request, if set
+	Command string
+	// ShowPayload prints the full payload of each request
+	ShowPayload bool
+
+	// mu serializes the handling of events, so their output doesn't interleave
+	mu sync.Mutex
+	// pending tracks events that are still being handled after the response
+	pending sync.WaitGroup
+}
+
+// forwardClient is used to forward events, so a hanging target doesn't block
+// the handling of further events forever
+var forwardClient = &http.Client{Timeout: 30 * time.Second}
+
+// ValidWebhookSignature checks the HMAC signature sent by Gitea for a payload
+func ValidWebhookSignature(secret string, payload []byte, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+func (l *WebhookListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	event := r.Header.Get("X-Gitea-Event")
+	delivery := r.Header.Get("X-Gitea-Delivery")
+	if len(l.Secret) != 0 && !ValidWebhookSignature(l.Secret, payload, r.Header.Get("X-Gitea-Signature")) {
+		l.mu.Lock()
+		fmt.Printf("rejected %s event (%s): invalid signature\n", event, delivery)
+		l.mu.Unlock()
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+
+	// respond before handling the event, as Gitea marks slow deliveries as failed
+	w.WriteHeader(http.StatusOK)
+
+	header := r.Header.Clone()
+	l.pending.Add(1)
+	go func() {
+		defer l.pending.Done()
+		l.handle(event, delivery, header, payload)
+	}()
+}
+
+func (l *WebhookListener) handle(event, delivery string, header http.Header, payload []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	print.WebhookEvent(event, delivery, payload, l.ShowPayload)
+
+	if len(l.ForwardURL) != 0 {
+		if err := l.forward(header, payload); err != nil {
+			fmt.Printf("  forwarding failed: %s\n", err)
+		}
+	}
+	if len(l.Command) != 0 {
+		if err := l.run(event, payload); err != nil {
+			fmt.Printf("  command failed: %s\n", err)
+		}
+	}
+}
+
+func (l *WebhookListener) forward(header http.Header, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, l.ForwardURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header = header
+	res, err := forwardClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	fmt.Printf("  forwarded to %s: %s\n", l.ForwardURL, res.Status)
+	return nil
+}
+
+func (l *WebhookListener) run(event string, payload []byte) error {
+	cmd := exec.Command("sh", "-c", l.Command)
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", l.Command)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "TEA_WEBHOOK_EVENT="+event)
+	return cmd.Run()
+}
+
+// ListenForWebhooks serves the listener on the given address until interrupted.
+// If scope is set, a webhook pointing to publicURL is created for the duration.
+func ListenForWebhooks(l *WebhookListener, addr string, scope *WebhookScope, publicURL string, events []string) error {
+	// bind first, so no webhook is registered if the address is unavailable
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: l}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Serve(ln)
+	}()
+	fmt.Printf("Listening for webhooks on %s\n", addr)
+
+	if scope != nil {
+		hook, err := scope.Create(gitea.CreateHookOption{
+			Type: gitea.HookTypeGitea,
+			Config: map[string]string{
+				"url":          publicURL,
+				"content_type": "json",
+				"secret":       l.Secret,
+			},
+			Events: events,
+			Active: true,
+		})
+		if err != nil {
+			server.Close()
+			return fmt.Errorf("could not register webhook: %s", err)
+		}
+		fmt.Printf("Registered webhook %d for %s on %s\n", hook.ID, publicURL, scope)
+		defer func() {
+			if err := scope.Delete(hook.ID); err != nil {
+				fmt.Printf("could not remove webhook %d: %s\n", hook.ID, err)
+				return
+			}
+			fmt.Printf("Removed webhook %d from %s\n", hook.ID, scope)
+		}()
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	select {
+	case err := <-errs:
+		return err
+	case <-interrupt:
+		err := server.Shutdown(context.Background())
+		l.pending.Wait()
+		return err
+	}
+}
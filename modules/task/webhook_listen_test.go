@@ -0,0 +1,55 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookListenerSignature(t *testing.T) {
+	payload := `{"action":"opened"}`
+	// echo -n '{"action":"opened"}' | openssl dgst -sha256 -hmac secret
+	signature := "d42142b53efbc7cf5cd20b6e074eb33707e0de3b368f698e6d6f6c824ffb8d37"
+	l := &WebhookListener{Secret: "secret"}
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+	req.Header.Set("X-Gitea-Event", "issues")
+	req.Header.Set("X-Gitea-Signature", signature)
+	res := httptest.NewRecorder()
+	l.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+	req.Header.Set("X-Gitea-Signature", "invalid")
+	res = httptest.NewRecorder()
+	l.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusForbidden, res.Code)
+}
+
+func TestWebhookListenerRespondsBeforeForwarding(t *testing.T) {
+	release := make(chan struct{})
+	var forwarded []string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		forwarded = append(forwarded, r.Header.Get("X-Gitea-Event"))
+	}))
+	defer target.Close()
+	l := &WebhookListener{ForwardURL: target.URL}
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	req.Header.Set("X-Gitea-Event", "push")
+	res := httptest.NewRecorder()
+	l.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	close(release)
+	l.pending.Wait()
+	assert.Equal(t, []string{"push"}, forwarded)
+}
@@ -0,0 +1,310 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"code.gitea.io/tea/modules/config"
+
+	"code.gitea.io/sdk/gitea"
+	"gopkg.in/yaml.v2"
+)
+
+// WebhookScope is the owner of a set of webhooks, either a repo or an organization
+type WebhookScope struct {
+	Login  *config.Login
+	Client *gitea.Client
+	Org    string
+	Owner  string
+	Repo   string
+}
+
+func (s WebhookScope) String() string {
+	if len(s.Org) != 0 {
+		return s.Org
+	}
+	return s.Owner + "/" + s.Repo
+}
+
+// List returns the webhooks of the scope
+func (s WebhookScope) List(opts gitea.ListHooksOptions) (hooks []*gitea.Hook, err error) {
+	if len(s.Org) != 0 {
+		hooks, _, err = s.Client.ListOrgHooks(s.Org, opts)
+	} else {
+		hooks, _, err = s.Client.ListRepoHooks(s.Owner, s.Repo, opts)
+	}
+	return
+}
+
+// ListAll returns all webhooks of the scope, iterating over all pages
+func (s WebhookScope) ListAll() ([]*gitea.Hook, error) {
+	var hooks []*gitea.Hook
+	for page := 1; ; page++ {
+		batch, err := s.List(gitea.ListHooksOptions{ListOptions: gitea.ListOptions{Page: page, PageSize: 50}})
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			return hooks, nil
+		}
+		hooks = append(hooks, batch...)
+	}
+}
+
+// Get returns a single webhook of the scope
+func (s WebhookScope) Get(id int64) (hook *gitea.Hook, err error) {
+	if len(s.Org) != 0 {
+		hook, _, err = s.Client.GetOrgHook(s.Org, id)
+	} else {
+		hook, _, err = s.Client.GetRepoHook(s.Owner, s.Repo, id)
+	}
+	return
+}
+
+// BranchFilter returns the branch filter of a webhook of the scope, which is
+// missing in gitea.Hook. Older servers don't return it, then known is false.
+func (s WebhookScope) BranchFilter(id int64) (filter string, known bool, err error) {
+	path := fmt.Sprintf("/repos/%s/%s/hooks/%d", url.PathEscape(s.Owner), url.PathEscape(s.Repo), id)
+	if len(s.Org) != 0 {
+		path = fmt.Sprintf("/orgs/%s/hooks/%d", url.PathEscape(s.Org), id)
+	}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(s.Login.URL, "/")+"/api/v1"+path, nil)
+	if err != nil {
+		return "", false, err
+	}
+	req.Header.Set("Authorization", "token "+s.Login.Token)
+	if len(s.Login.Sudo) != 0 {
+		req.Header.Set("Sudo", s.Login.Sudo)
+	}
+
+	resp, err := s.Login.HTTPClient().Do(req)
+	if err != nil {
+		return "", false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("could not get webhook %d: %s", id, resp.Status)
+	}
+
+	var hook struct {
+		BranchFilter *string `json:"branch_filter"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&hook); err != nil {
+		return "", false, err
+	}
+	if hook.BranchFilter == nil {
+		return "", false, nil
+	}
+	return *hook.BranchFilter, true, nil
+}
+
+// Create adds a webhook to the scope
+func (s WebhookScope) Create(opts gitea.CreateHookOption) (hook *gitea.Hook, err error) {
+	if len(s.Org) != 0 {
+		hook, _, err = s.Client.CreateOrgHook(s.Org, opts)
+	} else {
+		hook, _, err = s.Client.CreateRepoHook(s.Owner, s.Repo, opts)
+	}
+	return
+}
+
+// Edit changes a webhook of the scope
+func (s WebhookScope) Edit(id int64, opts gitea.EditHookOption) (err error) {
+	if len(s.Org) != 0 {
+		_, err = s.Client.EditOrgHook(s.Org, id, opts)
+	} else {
+		_, err = s.Client.EditRepoHook(s.Owner, s.Repo, id, opts)
+	}
+	return
+}
+
+// Delete removes a webhook from the scope
+func (s WebhookScope) Delete(id int64) (err error) {
+	if len(s.Org) != 0 {
+		_, err = s.Client.DeleteOrgHook(s.Org, id)
+	} else {
+		_, err = s.Client.DeleteRepoHook(s.Owner, s.Repo, id)
+	}
+	return
+}
+
+// WebhookConfig is the representation of a webhook in a YAML file
+type WebhookConfig struct {
+	Type        gitea.HookType `yaml:"type"`
+	URL         string         `yaml:"url"`
+	ContentType string         `yaml:"content_type,omitempty"`
+	Secret      string         `yaml:"secret,omitempty"`
+	Events      []string       `yaml:"events,flow"`
+	// BranchFilter is kept unchanged on update if it is not set
+	BranchFilter *string `yaml:"branch_filter,omitempty"`
+	// Active defaults to true if it is not set
+	Active *bool `yaml:"active,omitempty"`
+	// Config contains additional type specific settings, eg. the channel of a slack hook
+	Config map[string]string `yaml:"config,omitempty"`
+}
+
+// WebhookToConfig converts a webhook from the API into its file representation.
+// Secrets are not returned by the API, so they are missing. The branch filter
+// is only set if it is known, as older servers don't return it.
+func WebhookToConfig(hook *gitea.Hook, branchFilter string, branchFilterKnown bool) WebhookConfig {
+	active := hook.Active
+	c := WebhookConfig{
+		Type:        gitea.HookType(hook.Type),
+		URL:         hook.Config["url"],
+		ContentType: hook.Config["content_type"],
+		Events:      hook.Events,
+		Active:      &active,
+	}
+	if branchFilterKnown {
+		c.BranchFilter = &branchFilter
+	}
+	for k, v := range hook.Config {
+		if k == "url" || k == "content_type" {
+			continue
+		}
+		if c.Config == nil {
+			c.Config = make(map[string]string)
+		}
+		c.Config[k] = v
+	}
+	return c
+}
+
+// HookConfig returns the config map of the webhook as expected by the API
+func (c WebhookConfig) HookConfig() map[string]string {
+	config := map[string]string{"url": c.URL}
+	for k, v := range c.Config {
+		config[k] = v
+	}
+	if len(c.ContentType) != 0 {
+		config["content_type"] = c.ContentType
+	}
+	if len(c.Secret) != 0 {
+		config["secret"] = c.Secret
+	}
+	return config
+}
+
+// WriteWebhookConfigs stores webhooks as a YAML file
+func WriteWebhookConfigs(hooks []WebhookConfig, path string) error {
+	raw, err := yaml.Marshal(hooks)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, raw, 0600)
+}
+
+// ReadWebhookConfigs reads webhooks from a YAML file. Environment variables
+// in secrets are expanded, so they don't need to be stored in the file.
+func ReadWebhookConfigs(path string) ([]WebhookConfig, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	hooks, err := parseWebhookConfigs(raw)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %s", path, err)
+	}
+	return hooks, nil
+}
+
+func parseWebhookConfigs(raw []byte) ([]WebhookConfig, error) {
+	var hooks []WebhookConfig
+	if err := yaml.UnmarshalStrict(raw, &hooks); err != nil {
+		return nil, err
+	}
+	for i, h := range hooks {
+		if len(h.URL) == 0 {
+			return nil, fmt.Errorf("webhook %d has no url", i+1)
+		}
+		if len(h.Type) == 0 {
+			hooks[i].Type = gitea.HookTypeGitea
+		}
+		if h.Active == nil {
+			active := true
+			hooks[i].Active = &active
+		}
+		hooks[i].Secret = os.ExpandEnv(h.Secret)
+	}
+	return hooks, nil
+}
+
+// ImportWebhooks creates the given webhooks in the scope. Existing webhooks with
+// the same type and URL are updated instead.
+func ImportWebhooks(scope WebhookScope, hooks []WebhookConfig) error {
+	existing, err := scope.ListAll()
+	if err != nil {
+		return err
+	}
+
+	for _, h := range hooks {
+		var match *gitea.Hook
+		for _, e := range existing {
+			if gitea.HookType(e.Type) == h.Type && e.Config["url"] == h.URL {
+				match = e
+				break
+			}
+		}
+
+		if match == nil {
+			opts := gitea.CreateHookOption{
+				Type:   h.Type,
+				Config: h.HookConfig(),
+				Events: h.Events,
+				Active: h.Active == nil || *h.Active,
+			}
+			if h.BranchFilter != nil {
+				opts.BranchFilter = *h.BranchFilter
+			}
+			created, err := scope.Create(opts)
+			if err != nil {
+				return fmt.Errorf("could not create webhook %s: %s", h.URL, err)
+			}
+			fmt.Printf("%s: created webhook %d for %s\n", scope, created.ID, h.URL)
+			continue
+		}
+
+		// the API resets the branch filter if it is not sent, so keep the current one
+		branchFilter, err := h.branchFilter(scope, match.ID)
+		if err != nil {
+			return err
+		}
+		active := h.Active == nil || *h.Active
+		err = scope.Edit(match.ID, gitea.EditHookOption{
+			Config:       h.HookConfig(),
+			Events:       h.Events,
+			BranchFilter: branchFilter,
+			Active:       &active,
+		})
+		if err != nil {
+			return fmt.Errorf("could not update webhook %d: %s", match.ID, err)
+		}
+		fmt.Printf("%s: updated webhook %d for %s\n", scope, match.ID, h.URL)
+	}
+	return nil
+}
+
+// branchFilter returns the branch filter to send when updating the existing webhook
+// with the given id, which is the current one of the webhook if none is set
+func (c WebhookConfig) branchFilter(scope WebhookScope, id int64) (string, error) {
+	if c.BranchFilter != nil {
+		return *c.BranchFilter, nil
+	}
+	filter, known, err := scope.BranchFilter(id)
+	if err != nil {
+		return "", err
+	}
+	if !known {
+		return "", fmt.Errorf("could not update webhook %d: the server doesn't return its branch filter, so set branch_filter to keep it ('*' for all branches)", id)
+	}
+	return filter, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2021 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package task
+
+import (
+	"os"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWebhookConfigs(t *testing.T) {
+	os.Setenv("TEA_TEST_HOOK_SECRET", "s3cret")
+	defer os.Unsetenv("TEA_TEST_HOOK_SECRET")
+
+	hooks, err := parseWebhookConfigs([]byte(`
+- url: https://ci.example.com/hook
+  secret: ${TEA_TEST_HOOK_SECRET}
+  events: [push]
+- type: slack
+  url: https://hooks.slack.com/x
+  branch_filter: main
+  active: false
+  config:
+    channel: "#ci"
+`))
+	assert.NoError(t, err)
+	if assert.Len(t, hooks, 2) {
+		assert.Equal(t, gitea.HookTypeGitea, hooks[0].Type)
+		assert.Equal(t, "s3cret", hooks[0].Secret)
+		assert.Nil(t, hooks[0].BranchFilter, "missing branch filter must stay unset")
+		if assert.NotNil(t, hooks[0].Active) {
+			assert.True(t, *hooks[0].Active, "missing active must default to true")
+		}
+
+		assert.Equal(t, gitea.HookTypeSlack, hooks[1].Type)
+		if assert.NotNil(t, hooks[1].BranchFilter) {
+			assert.Equal(t, "main", *hooks[1].BranchFilter)
+		}
+		if assert.NotNil(t, hooks[1].Active) {
+			assert.False(t, *hooks[1].Active)
+		}
+		assert.Equal(t, map[string]string{"channel": "#ci"}, hooks[1].Config)
+	}
+
+	_, err = parseWebhookConfigs([]byte(`- type: gitea`))
+	assert.Error(t, err)
+	_, err = parseWebhookConfigs([]byte(`- url: https://x
+  unknown: 1`))
+	assert.Error(t, err)
+}